@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -33,11 +34,41 @@ type RunService interface {
 	ListRuns(ctx context.Context, workspaceID string, opts *tfe.RunListOptions) (*tfe.RunList, error)
 	ReadRun(ctx context.Context, runID string) (*tfe.Run, error)
 	ReadRunWithApply(ctx context.Context, runID string) (*tfe.Run, error)
+	CreateRun(ctx context.Context, opts tfe.RunCreateOptions) (*tfe.Run, error)
+}
+
+// RunActionService provides lifecycle actions on HCP Terraform runs.
+type RunActionService interface {
+	ApplyRun(ctx context.Context, runID string, comment string) error
+	DiscardRun(ctx context.Context, runID string, comment string) error
+	CancelRun(ctx context.Context, runID string, comment string) error
+	ForceCancelRun(ctx context.Context, runID string, comment string) error
+}
+
+// RunEventService provides the event history of HCP Terraform runs.
+type RunEventService interface {
+	ListRunEvents(ctx context.Context, runID string) ([]*tfe.RunEvent, error)
 }
 
 // PlanService provides operations on HCP Terraform plans.
 type PlanService interface {
 	ReadPlanJSONOutput(ctx context.Context, planID string) ([]byte, error)
+	ReadPlanLogs(ctx context.Context, planID string) (io.Reader, error)
+}
+
+// PolicyService provides the policy evaluation results of HCP Terraform runs.
+// Sentinel policies are reported as policy checks, while OPA policies are
+// reported as policy evaluations within the run's task stages.
+type PolicyService interface {
+	ListPolicyChecks(ctx context.Context, runID string) ([]*tfe.PolicyCheck, error)
+	ReadPolicyCheckLogs(ctx context.Context, policyCheckID string) (io.Reader, error)
+	ListPolicyEvaluations(ctx context.Context, runID string) ([]*tfe.PolicyEvaluation, error)
+	ListPolicySetOutcomes(ctx context.Context, policyEvaluationID string) ([]*tfe.PolicySetOutcome, error)
+}
+
+// TaskService provides the run task results of HCP Terraform runs.
+type TaskService interface {
+	ListTaskStages(ctx context.Context, runID string) ([]*tfe.TaskStage, error)
 }
 
 // ApplyService provides operations on HCP Terraform applies.
@@ -45,6 +76,11 @@ type ApplyService interface {
 	ReadApplyLogs(ctx context.Context, applyID string) (io.Reader, error)
 }
 
+// LogService reads raw chunks of a plan or apply log.
+type LogService interface {
+	ReadLogChunk(ctx context.Context, logReadURL string, offset int64) ([]byte, error)
+}
+
 // VariableService provides operations on HCP Terraform workspace variables.
 type VariableService interface {
 	ListVariables(ctx context.Context, workspaceID string, opts *tfe.VariableListOptions) (*tfe.VariableList, error)
@@ -145,6 +181,7 @@ type SubscriptionService interface {
 // ClientWrapper wraps the go-tfe client and implements all service interfaces.
 type ClientWrapper struct {
 	client  *tfe.Client
+	http    *http.Client
 	address string
 	token   string
 }
@@ -160,12 +197,22 @@ func NewClientWrapper() (*ClientWrapper, error) {
 	}
 
 	if token == "" {
+		if profile := viper.GetString("profile"); profile != "" {
+			return nil, fmt.Errorf("API token is required for profile %q: set TFE_TOKEN environment variable, 'token' in the profile, or run 'terraform login %s'", profile, hostnameFromAddress(address))
+		}
 		return nil, fmt.Errorf("API token is required: set TFE_TOKEN environment variable, 'token' in config file, or run 'terraform login'")
 	}
 
+	timeout, err := requestTimeout()
+	if err != nil {
+		return nil, err
+	}
+	httpClient := newHTTPClient(timeout)
+
 	config := &tfe.Config{
-		Token:   token,
-		Address: address,
+		Token:      token,
+		Address:    address,
+		HTTPClient: httpClient,
 	}
 
 	client, err := tfe.NewClient(config)
@@ -173,7 +220,24 @@ func NewClientWrapper() (*ClientWrapper, error) {
 		return nil, fmt.Errorf("failed to create HCP Terraform client: %w", err)
 	}
 
-	return &ClientWrapper{client: client, address: address, token: token}, nil
+	return &ClientWrapper{client: client, http: httpClient, address: address, token: token}, nil
+}
+
+// requestTimeout returns the per-request API timeout from the 'timeout'
+// setting, or DefaultRequestTimeout when it is not set.
+func requestTimeout() (time.Duration, error) {
+	value := viper.GetString("timeout")
+	if value == "" {
+		return DefaultRequestTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err == nil && timeout <= 0 {
+		err = fmt.Errorf("must be positive")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", value, err)
+	}
+	return timeout, nil
 }
 
 // hostnameFromAddress extracts hostname from an address URL.
@@ -214,21 +278,179 @@ func (c *ClientWrapper) ListRuns(ctx context.Context, workspaceID string, opts *
 
 func (c *ClientWrapper) ReadRun(ctx context.Context, runID string) (*tfe.Run, error) {
 	return c.client.Runs.ReadWithOptions(ctx, runID, &tfe.RunReadOptions{
-		Include: []tfe.RunIncludeOpt{tfe.RunPlan},
+		Include: []tfe.RunIncludeOpt{tfe.RunPlan, tfe.RunCostEstimate},
 	})
 }
 
 func (c *ClientWrapper) ReadRunWithApply(ctx context.Context, runID string) (*tfe.Run, error) {
 	return c.client.Runs.ReadWithOptions(ctx, runID, &tfe.RunReadOptions{
-		Include: []tfe.RunIncludeOpt{tfe.RunPlan, tfe.RunApply},
+		Include: []tfe.RunIncludeOpt{tfe.RunPlan, tfe.RunApply, tfe.RunCostEstimate},
 	})
 }
 
+// CreateRun queues a new run.
+func (c *ClientWrapper) CreateRun(ctx context.Context, opts tfe.RunCreateOptions) (*tfe.Run, error) {
+	return c.client.Runs.Create(ctx, opts)
+}
+
+// ApplyRun confirms a planned run so that it proceeds to apply.
+func (c *ClientWrapper) ApplyRun(ctx context.Context, runID string, comment string) error {
+	return c.client.Runs.Apply(ctx, runID, tfe.RunApplyOptions{Comment: optionalString(comment)})
+}
+
+// DiscardRun discards a run that is waiting for confirmation.
+func (c *ClientWrapper) DiscardRun(ctx context.Context, runID string, comment string) error {
+	return c.client.Runs.Discard(ctx, runID, tfe.RunDiscardOptions{Comment: optionalString(comment)})
+}
+
+// CancelRun interrupts a run that is currently planning or applying.
+func (c *ClientWrapper) CancelRun(ctx context.Context, runID string, comment string) error {
+	return c.client.Runs.Cancel(ctx, runID, tfe.RunCancelOptions{Comment: optionalString(comment)})
+}
+
+// ForceCancelRun ends a run immediately and unlocks its workspace.
+func (c *ClientWrapper) ForceCancelRun(ctx context.Context, runID string, comment string) error {
+	return c.client.Runs.ForceCancel(ctx, runID, tfe.RunForceCancelOptions{Comment: optionalString(comment)})
+}
+
+// optionalString returns nil for an empty string so that it is omitted from the request.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 // ReadPlanJSONOutput reads the JSON output of a plan.
 func (c *ClientWrapper) ReadPlanJSONOutput(ctx context.Context, planID string) ([]byte, error) {
 	return c.client.Plans.ReadJSONOutput(ctx, planID)
 }
 
+// ReadPlanLogs reads the log output for a plan.
+func (c *ClientWrapper) ReadPlanLogs(ctx context.Context, planID string) (io.Reader, error) {
+	return c.client.Plans.Logs(ctx, planID)
+}
+
+// ListRunEvents lists the events of a run with their actors and comments.
+func (c *ClientWrapper) ListRunEvents(ctx context.Context, runID string) ([]*tfe.RunEvent, error) {
+	list, err := c.client.RunEvents.List(ctx, runID, &tfe.RunEventListOptions{
+		Include: []tfe.RunEventIncludeOpt{tfe.RunEventActor, tfe.RunEventComment},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// ListPolicyChecks lists all Sentinel policy checks of a run.
+func (c *ClientWrapper) ListPolicyChecks(ctx context.Context, runID string) ([]*tfe.PolicyCheck, error) {
+	opts := &tfe.PolicyCheckListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	var checks []*tfe.PolicyCheck
+	for {
+		list, err := c.client.PolicyChecks.List(ctx, runID, opts)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return checks, nil
+		}
+		opts.PageNumber = list.NextPage
+	}
+}
+
+// ReadPolicyCheckLogs reads the log output for a policy check.
+func (c *ClientWrapper) ReadPolicyCheckLogs(ctx context.Context, policyCheckID string) (io.Reader, error) {
+	return c.client.PolicyChecks.Logs(ctx, policyCheckID)
+}
+
+// ListPolicyEvaluations lists the OPA policy evaluations of all task stages of a run.
+func (c *ClientWrapper) ListPolicyEvaluations(ctx context.Context, runID string) ([]*tfe.PolicyEvaluation, error) {
+	stages, err := c.listTaskStages(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluations []*tfe.PolicyEvaluation
+	for _, stage := range stages {
+		if len(stage.PolicyEvaluations) == 0 {
+			continue
+		}
+		opts := &tfe.PolicyEvaluationListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+		for {
+			list, err := c.client.PolicyEvaluations.List(ctx, stage.ID, opts)
+			if err != nil {
+				return nil, err
+			}
+			evaluations = append(evaluations, list.Items...)
+			if list.Pagination == nil || list.NextPage == 0 {
+				break
+			}
+			opts.PageNumber = list.NextPage
+		}
+	}
+	return evaluations, nil
+}
+
+// ListPolicySetOutcomes lists the policy set outcomes of an OPA policy evaluation.
+func (c *ClientWrapper) ListPolicySetOutcomes(ctx context.Context, policyEvaluationID string) ([]*tfe.PolicySetOutcome, error) {
+	opts := &tfe.PolicySetOutcomeListOptions{ListOptions: &tfe.ListOptions{PageSize: 100}}
+	var outcomes []*tfe.PolicySetOutcome
+	for {
+		list, err := c.client.PolicySetOutcomes.List(ctx, policyEvaluationID, opts)
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return outcomes, nil
+		}
+		opts.PageNumber = list.NextPage
+	}
+}
+
+// ListTaskStages lists the task stages of a run. The task results of each
+// stage are read along with it, as the stage list only carries their IDs.
+func (c *ClientWrapper) ListTaskStages(ctx context.Context, runID string) ([]*tfe.TaskStage, error) {
+	stages, err := c.listTaskStages(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*tfe.TaskStage, 0, len(stages))
+	for _, stage := range stages {
+		if len(stage.TaskResults) == 0 {
+			result = append(result, stage)
+			continue
+		}
+		full, err := c.client.TaskStages.Read(ctx, stage.ID, &tfe.TaskStageReadOptions{
+			Include: []tfe.TaskStageIncludeOpt{tfe.TaskStageTaskResults},
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, full)
+	}
+	return result, nil
+}
+
+// listTaskStages lists all task stages of a run.
+func (c *ClientWrapper) listTaskStages(ctx context.Context, runID string) ([]*tfe.TaskStage, error) {
+	opts := &tfe.TaskStageListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	var stages []*tfe.TaskStage
+	for {
+		list, err := c.client.TaskStages.List(ctx, runID, opts)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, list.Items...)
+		if list.Pagination == nil || list.NextPage == 0 {
+			return stages, nil
+		}
+		opts.PageNumber = list.NextPage
+	}
+}
+
 // ReadApplyLogs reads the log output for an apply.
 func (c *ClientWrapper) ReadApplyLogs(ctx context.Context, applyID string) (io.Reader, error) {
 	return c.client.Applies.Logs(ctx, applyID)
@@ -256,13 +478,6 @@ func (c *ClientWrapper) ListProjects(ctx context.Context, org string, opts *tfe.
 
 // ListExplorerWorkspaces queries the Explorer API for workspace data.
 func (c *ClientWrapper) ListExplorerWorkspaces(ctx context.Context, org string, opts ExplorerListOptions) (*ExplorerWorkspaceList, error) {
-	address := c.address
-	if address == "" {
-		address = "https://app.terraform.io"
-	}
-
-	apiURL := strings.TrimRight(address, "/") + "/api/v2/organizations/" + url.PathEscape(org) + "/explorer"
-
 	params := url.Values{}
 	params.Set("type", "workspaces")
 	params.Set("page[size]", "100")
@@ -282,28 +497,9 @@ func (c *ClientWrapper) ListExplorerWorkspaces(ctx context.Context, org string,
 		params.Set(fmt.Sprintf("filter[%d][current-run-status][is][0]", filterIdx), opts.RunStatus)
 	}
 
-	fullURL := apiURL + "?" + params.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	body, err := c.getJSONAPI(ctx, "explorer", "organizations/"+url.PathEscape(org)+"/explorer", params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/vnd.api+json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch explorer data: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("explorer endpoint returned HTTP %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read explorer response: %w", err)
+		return nil, err
 	}
 
 	return parseExplorerWorkspacesResponse(body)
@@ -381,65 +577,35 @@ func parseTags(raw string) []string {
 	return tags
 }
 
+// HasTag reports whether the workspace has a tag matching filter. A bare
+// "key" filter matches a key-only tag or any key-value tag with that key,
+// while a "key:value" filter matches only that exact key-value tag.
+func (w ExplorerWorkspace) HasTag(filter string) bool {
+	fKey, fValue, fHasValue := strings.Cut(filter, ":")
+	for _, t := range w.Tags {
+		key, value, hasValue := strings.Cut(t, ":")
+		if key != fKey {
+			continue
+		}
+		if !fHasValue || (hasValue && value == fValue) {
+			return true
+		}
+	}
+	return false
+}
+
 // ReadCurrentAssessment fetches the current assessment result for a workspace.
 // Returns nil, nil if assessment is disabled or has not run (HTTP 404).
-// Retries on HTTP 429 (rate limit) with backoff.
 func (c *ClientWrapper) ReadCurrentAssessment(ctx context.Context, workspaceID string) (*AssessmentResult, error) {
-	address := c.address
-	if address == "" {
-		address = "https://app.terraform.io"
+	body, err := c.getJSONAPI(ctx, "assessment", "workspaces/"+url.PathEscape(workspaceID)+"/current-assessment-result", nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
 	}
-
-	apiURL := strings.TrimRight(address, "/") + "/api/v2/workspaces/" + url.PathEscape(workspaceID) + "/current-assessment-result"
-
-	const maxRetries = 3
-
-	for attempt := range maxRetries {
-		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
-		req.Header.Set("Authorization", "Bearer "+c.token)
-		req.Header.Set("Content-Type", "application/vnd.api+json")
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch assessment result: %w", err)
-		}
-
-		if resp.StatusCode == http.StatusTooManyRequests {
-			_ = resp.Body.Close()
-			if attempt == maxRetries-1 {
-				return nil, fmt.Errorf("assessment endpoint rate limited (HTTP 429) after %d retries", maxRetries)
-			}
-			wait := retryAfterDuration(resp.Header.Get("Retry-After"), attempt)
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(wait):
-				continue
-			}
-		}
-
-		defer func() { _ = resp.Body.Close() }()
-
-		if resp.StatusCode == http.StatusNotFound {
-			return nil, nil
-		}
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("assessment endpoint returned HTTP %d", resp.StatusCode)
-		}
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read assessment response: %w", err)
-		}
-
-		return parseAssessmentResponse(body)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("assessment endpoint: unexpected retry loop exit")
+	return parseAssessmentResponse(body)
 }
 
 // retryAfterDuration parses the Retry-After header or falls back to exponential backoff.
@@ -483,33 +649,9 @@ func parseAssessmentResponse(body []byte) (*AssessmentResult, error) {
 // ReadAssessmentDriftDetails fetches the JSON output for an assessment result
 // and extracts the drifted resource details from the resource_drift field.
 func (c *ClientWrapper) ReadAssessmentDriftDetails(ctx context.Context, assessmentID string) ([]DriftedResource, error) {
-	address := c.address
-	if address == "" {
-		address = "https://app.terraform.io"
-	}
-
-	apiURL := strings.TrimRight(address, "/") + "/api/v2/assessment-results/" + url.PathEscape(assessmentID) + "/json-output"
-
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	body, err := c.getJSONAPI(ctx, "assessment json-output", "assessment-results/"+url.PathEscape(assessmentID)+"/json-output", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/vnd.api+json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch assessment json-output: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("assessment json-output endpoint returned HTTP %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read assessment json-output: %w", err)
+		return nil, err
 	}
 
 	return parseAssessmentJSONOutput(body)
@@ -574,33 +716,9 @@ func parseAssessmentJSONOutput(body []byte) ([]DriftedResource, error) {
 
 // ReadSubscription fetches subscription info from the organizations API.
 func (c *ClientWrapper) ReadSubscription(ctx context.Context, org string) (*SubscriptionInfo, error) {
-	address := c.address
-	if address == "" {
-		address = "https://app.terraform.io"
-	}
-
-	apiURL := strings.TrimRight(address, "/") + "/api/v2/organizations/" + url.PathEscape(org) + "/subscription"
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/vnd.api+json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("subscription endpoint returned HTTP %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.getJSONAPI(ctx, "subscription", "organizations/"+url.PathEscape(org)+"/subscription", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read subscription response: %w", err)
+		return nil, err
 	}
 
 	return parseSubscriptionResponse(body)
@@ -2,25 +2,44 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v85/github"
+	tfe "github.com/hashicorp/go-tfe"
 	"github.com/spf13/viper"
 )
 
 // newTestClientWrapper creates a ClientWrapper pointing to the given test server URL.
+// Retries are performed without waiting so tests stay fast.
 func newTestClientWrapper(serverURL string) *ClientWrapper {
 	return &ClientWrapper{
+		http:    newTestHTTPClient(),
 		address: serverURL,
 		token:   "test-token",
 	}
 }
 
+// newTestHTTPClient creates an HTTP client using retryTransport with no backoff.
+func newTestHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			timeout:    DefaultRequestTimeout,
+			maxRetries: defaultMaxRetries,
+			backoff:    func(string, int) time.Duration { return 0 },
+		},
+	}
+}
+
 // --- hostnameFromAddress ---
 
 func TestHostnameFromAddress_NoScheme(t *testing.T) {
@@ -418,6 +437,27 @@ func TestListExplorerWorkspaces_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestExplorerWorkspace_HasTag(t *testing.T) {
+	ws := ExplorerWorkspace{Tags: []string{"production", "repo:frontend"}}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"production", true},
+		{"repo", true},
+		{"repo:frontend", true},
+		{"repo:backend", false},
+		{"production:true", false},
+		{"staging", false},
+	}
+	for _, tt := range tests {
+		if got := ws.HasTag(tt.filter); got != tt.want {
+			t.Errorf("HasTag(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
 // --- resolveGitHubToken ---
 
 func TestResolveGitHubToken_EnvVar(t *testing.T) {
@@ -427,7 +467,7 @@ func TestResolveGitHubToken_EnvVar(t *testing.T) {
 	viper.Set("GITHUB_TOKEN", "env-github-token")
 	defer viper.Reset()
 
-	token := resolveGitHubToken()
+	token := resolveGitHubToken("github.com")
 	// Token may come from gh CLI if installed; if not, should be env-github-token
 	if token == "" {
 		t.Error("expected non-empty token from GITHUB_TOKEN")
@@ -439,7 +479,7 @@ func TestResolveGitHubToken_ConfigFile(t *testing.T) {
 	viper.Set("github-token", "config-github-token")
 	defer viper.Reset()
 
-	token := resolveGitHubToken()
+	token := resolveGitHubToken("github.com")
 	// Token may come from gh CLI if installed; if not, should be config-github-token
 	if token == "" {
 		t.Error("expected non-empty token from github-token config")
@@ -478,6 +518,102 @@ func TestNewGitHubClientWrapper_WithToken(t *testing.T) {
 	}
 }
 
+func TestNewGitHubClientWrapper_GitHubComAsURL(t *testing.T) {
+	for _, host := range []string{"https://github.com", "https://github.com/", "github.com/"} {
+		t.Run(host, func(t *testing.T) {
+			viper.Reset()
+			t.Setenv("PATH", "")
+			viper.Set("github-token", "test-github-token")
+			viper.Set("github-host", host)
+			defer viper.Reset()
+
+			gcw, err := NewGitHubClientWrapper()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := gcw.client.BaseURL.String(); got != "https://api.github.com/" {
+				t.Errorf("expected the github.com API, got %q", got)
+			}
+		})
+	}
+}
+
+func TestNewGitHubClientWrapper_EnterpriseHost(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/repos/owner/repo/pulls/1":
+			_, _ = fmt.Fprint(w, `{"number": 1, "head": {"sha": "abc123"}}`)
+		case "/api/v3/repos/owner/repo/commits/abc123/statuses":
+			_, _ = fmt.Fprint(w, `[{"context": "HCP Terraform / my-org / my-workspace", "target_url": "https://app.terraform.io/app/my-org/workspaces/my-workspace/runs/run-ghes"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	viper.Reset()
+	t.Setenv("PATH", "")
+	viper.Set("GH_ENTERPRISE_TOKEN", "test-github-token")
+	viper.Set("github-host", ts.URL)
+	defer viper.Reset()
+
+	gcw, err := NewGitHubClientWrapper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	runID, err := gcw.GetRunIDFromPR(context.Background(), "owner", "repo", 1, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runID != "run-ghes" {
+		t.Errorf("expected run-ghes, got %q", runID)
+	}
+}
+
+func TestResolveGitHubToken_EnterpriseHost(t *testing.T) {
+	t.Setenv("PATH", "")
+
+	tests := []struct {
+		name     string
+		settings map[string]string
+		want     string
+	}{
+		{"github.com token is not used", map[string]string{"GITHUB_TOKEN": "dotcom-token", "github-token": "dotcom-config-token"}, ""},
+		{"GH_ENTERPRISE_TOKEN", map[string]string{"GITHUB_TOKEN": "dotcom-token", "GH_ENTERPRISE_TOKEN": "ghes-token"}, "ghes-token"},
+		{"GITHUB_ENTERPRISE_TOKEN", map[string]string{"GITHUB_ENTERPRISE_TOKEN": "ghes-token"}, "ghes-token"},
+		{"config file", map[string]string{"github-token": "dotcom-config-token", "github-enterprise-token": "ghes-config-token"}, "ghes-config-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			for k, v := range tt.settings {
+				viper.Set(k, v)
+			}
+
+			if got := resolveGitHubToken("ghe.example.com"); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewGitHubClientWrapper_EnterpriseHostNoToken(t *testing.T) {
+	viper.Reset()
+	t.Setenv("PATH", "")
+	viper.Set("GITHUB_TOKEN", "dotcom-token")
+	viper.Set("github-host", "ghe.example.com")
+	defer viper.Reset()
+
+	_, err := NewGitHubClientWrapper()
+	if err == nil || !strings.Contains(err.Error(), "GitHub token for ghe.example.com is required") {
+		t.Errorf("expected token required error for the enterprise host, got: %v", err)
+	}
+}
+
 // --- GetRunIDFromPR ---
 
 func TestGetRunIDFromPR_SingleRun(t *testing.T) {
@@ -625,3 +761,585 @@ func TestGetRunIDFromPR_WorkspaceNotFound(t *testing.T) {
 		t.Errorf("expected workspace not found error, got: %v", err)
 	}
 }
+
+func TestGetRunIDFromPR_EnterpriseHost(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/pulls/6"):
+			_, _ = fmt.Fprint(w, `{"number": 6, "head": {"sha": "22222222"}}`)
+		case strings.Contains(r.URL.Path, "/commits/22222222/statuses"):
+			_, _ = fmt.Fprint(w, `[
+				{"context": "HCP Terraform / my-org / ws-cloud", "target_url": "https://app.terraform.io/app/my-org/workspaces/ws-cloud/runs/run-cloud"},
+				{"context": "Terraform Enterprise / my-org / ws-tfe", "target_url": "https://tfe.example.com/app/my-org/workspaces/ws-tfe/runs/run-tfe"}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL + "/")
+	ghClient := github.NewClient(nil)
+	ghClient.BaseURL = baseURL
+	gcw := &GitHubClientWrapper{client: ghClient, address: "https://tfe.example.com"}
+
+	runID, err := gcw.GetRunIDFromPR(context.Background(), "owner", "repo", 6, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runID != "run-tfe" {
+		t.Errorf("expected run-tfe, got %q", runID)
+	}
+}
+
+func TestGetRunIDFromPR_CheckRuns(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/pulls/7"):
+			_, _ = fmt.Fprint(w, `{"number": 7, "head": {"sha": "33333333"}}`)
+		case strings.Contains(r.URL.Path, "/commits/33333333/statuses"):
+			_, _ = fmt.Fprint(w, `[]`)
+		case strings.Contains(r.URL.Path, "/commits/33333333/check-runs"):
+			_, _ = fmt.Fprint(w, `{"total_count": 2, "check_runs": [
+				{"name": "lint", "details_url": "https://ci.example.com/builds/1"},
+				{"name": "Terraform Cloud/my-org/my-workspace", "details_url": "https://app.terraform.io/app/my-org/workspaces/my-workspace/runs/run-check"}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL + "/")
+	ghClient := github.NewClient(nil)
+	ghClient.BaseURL = baseURL
+	gcw := &GitHubClientWrapper{client: ghClient}
+
+	runID, err := gcw.GetRunIDFromPR(context.Background(), "owner", "repo", 7, "my-workspace")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runID != "run-check" {
+		t.Errorf("expected run-check, got %q", runID)
+	}
+}
+
+func TestGetRunIDFromPR_StatusAndCheckRunForSameRun(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/pulls/8"):
+			_, _ = fmt.Fprint(w, `{"number": 8, "head": {"sha": "44444444"}}`)
+		case strings.Contains(r.URL.Path, "/commits/44444444/statuses"):
+			_, _ = fmt.Fprint(w, `[{"context": "Terraform Cloud/my-org/my-workspace", "target_url": "https://app.terraform.io/app/my-org/workspaces/my-workspace/runs/run-same"}]`)
+		case strings.Contains(r.URL.Path, "/commits/44444444/check-runs"):
+			_, _ = fmt.Fprint(w, `{"total_count": 1, "check_runs": [
+				{"name": "HCP Terraform / my-workspace", "details_url": "https://app.terraform.io/app/my-org/workspaces/my-workspace/runs/run-same"}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL + "/")
+	ghClient := github.NewClient(nil)
+	ghClient.BaseURL = baseURL
+	gcw := &GitHubClientWrapper{client: ghClient}
+
+	runs, err := gcw.ListRunsFromPR(context.Background(), "owner", "repo", 8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].RunID != "run-same" || runs[0].Context != "Terraform Cloud/my-org/my-workspace" {
+		t.Errorf("expected the run to be listed once, got %+v", runs)
+	}
+
+	runID, err := gcw.GetRunIDFromPR(context.Background(), "owner", "repo", 8, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runID != "run-same" {
+		t.Errorf("expected run-same, got %q", runID)
+	}
+}
+
+func TestGetRunIDFromPR_PaginatedStatuses(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/pulls/8"):
+			_, _ = fmt.Fprint(w, `{"number": 8, "head": {"sha": "44444444"}}`)
+		case strings.Contains(r.URL.Path, "/commits/44444444/statuses"):
+			if r.URL.Query().Get("page") != "2" {
+				w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, ts.URL, r.URL.Path))
+				_, _ = fmt.Fprint(w, `[{"context": "ci/build", "target_url": "https://ci.example.com/builds/1"}]`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `[{"context": "HCP Terraform / my-org / my-workspace", "target_url": "https://app.terraform.io/app/my-org/workspaces/my-workspace/runs/run-page2"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL + "/")
+	ghClient := github.NewClient(nil)
+	ghClient.BaseURL = baseURL
+	gcw := &GitHubClientWrapper{client: ghClient}
+
+	runID, err := gcw.GetRunIDFromPR(context.Background(), "owner", "repo", 8, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runID != "run-page2" {
+		t.Errorf("expected run-page2, got %q", runID)
+	}
+}
+
+func TestGetRunIDFromPR_CheckRunsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/pulls/9"):
+			_, _ = fmt.Fprint(w, `{"number": 9, "head": {"sha": "55555555"}}`)
+		case strings.Contains(r.URL.Path, "/commits/55555555/statuses"):
+			_, _ = fmt.Fprint(w, `[]`)
+		case strings.Contains(r.URL.Path, "/commits/55555555/check-runs"):
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL + "/")
+	ghClient := github.NewClient(nil)
+	ghClient.BaseURL = baseURL
+	gcw := &GitHubClientWrapper{client: ghClient}
+
+	_, err := gcw.GetRunIDFromPR(context.Background(), "owner", "repo", 9, "")
+	if err == nil || !strings.Contains(err.Error(), "failed to get check runs") {
+		t.Errorf("expected 'failed to get check runs' error, got: %v", err)
+	}
+}
+
+// --- NewGitLabClientWrapper ---
+
+func TestNewGitLabClientWrapper_NoToken(t *testing.T) {
+	viper.Reset()
+	t.Setenv("PATH", "")
+	t.Setenv("GITLAB_TOKEN", "")
+
+	_, err := NewGitLabClientWrapper()
+	if err == nil {
+		t.Fatal("expected error when no GitLab token, got nil")
+	}
+	if !strings.Contains(err.Error(), "GitLab token is required") {
+		t.Errorf("expected 'GitLab token is required' error, got: %v", err)
+	}
+}
+
+func TestResolveGitLabToken_SelfManagedHost(t *testing.T) {
+	t.Setenv("PATH", "")
+
+	tests := []struct {
+		name     string
+		settings map[string]string
+		want     string
+	}{
+		{"gitlab.com token is not used", map[string]string{"GITLAB_TOKEN": "dotcom-token", "gitlab-token": "dotcom-config-token"}, ""},
+		{"GITLAB_SELF_MANAGED_TOKEN", map[string]string{"GITLAB_TOKEN": "dotcom-token", "GITLAB_SELF_MANAGED_TOKEN": "self-managed-token"}, "self-managed-token"},
+		{"config file", map[string]string{"gitlab-token": "dotcom-config-token", "gitlab-self-managed-token": "self-managed-config-token"}, "self-managed-config-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			for k, v := range tt.settings {
+				viper.Set(k, v)
+			}
+
+			if got := resolveGitLabToken("gitlab.example.com"); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewGitLabClientWrapper_SelfManagedHostNoToken(t *testing.T) {
+	viper.Reset()
+	t.Setenv("PATH", "")
+	viper.Set("GITLAB_TOKEN", "dotcom-token")
+	viper.Set("gitlab-host", "https://gitlab.example.com")
+	defer viper.Reset()
+
+	_, err := NewGitLabClientWrapper()
+	if err == nil || !strings.Contains(err.Error(), "GitLab token for gitlab.example.com is required") {
+		t.Errorf("expected token required error for the self-managed host, got: %v", err)
+	}
+}
+
+func TestNewGitLabClientWrapper_Timeout(t *testing.T) {
+	viper.Reset()
+	t.Setenv("PATH", "")
+	viper.Set("gitlab-token", "test-gitlab-token")
+	defer viper.Reset()
+
+	viper.Set("timeout", "45s")
+	gcw, err := NewGitLabClientWrapper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transport, ok := gcw.http.Transport.(*retryTransport)
+	if !ok {
+		t.Fatalf("expected retrying transport, got %T", gcw.http.Transport)
+	}
+	if transport.timeout != 45*time.Second {
+		t.Errorf("expected timeout 45s, got %s", transport.timeout)
+	}
+
+	viper.Set("timeout", "abc")
+	if _, err := NewGitLabClientWrapper(); err == nil || !strings.Contains(err.Error(), "invalid timeout") {
+		t.Errorf("expected invalid timeout error, got: %v", err)
+	}
+}
+
+// --- GetRunIDFromMR ---
+
+// newTestGitLabServer serves a merge request whose head commit has the given
+// pages of commit statuses.
+func newTestGitLabServer(t *testing.T, sha string, pages ...string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "test-gitlab-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fsub%2Fproject/merge_requests/7":
+			_, _ = fmt.Fprintf(w, `{"iid": 7, "sha": %q}`, sha)
+		case "/api/v4/projects/group%2Fsub%2Fproject/repository/commits/" + sha + "/statuses":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page < 1 || page > len(pages) {
+				_, _ = fmt.Fprint(w, `[]`)
+				return
+			}
+			if page < len(pages) {
+				w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+			}
+			_, _ = fmt.Fprint(w, pages[page-1])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestGitLabClient(ts *httptest.Server) *GitLabClientWrapper {
+	return &GitLabClientWrapper{http: ts.Client(), baseURL: ts.URL + "/api/v4", token: "test-gitlab-token"}
+}
+
+func TestGetRunIDFromMR_SingleRun(t *testing.T) {
+	ts := newTestGitLabServer(t, "abc123",
+		`[{"name": "lint", "target_url": "https://gitlab.com/group/sub/project/-/jobs/1"},
+		  {"name": "HCP Terraform / my-org / my-workspace", "target_url": "https://app.terraform.io/app/my-org/workspaces/my-workspace/runs/run-mr123"}]`)
+	defer ts.Close()
+
+	runID, err := newTestGitLabClient(ts).GetRunIDFromMR(context.Background(), "group/sub/project", 7, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runID != "run-mr123" {
+		t.Errorf("expected run-mr123, got %q", runID)
+	}
+}
+
+func TestGetRunIDFromMR_PaginatedWithWorkspaceFilter(t *testing.T) {
+	ts := newTestGitLabServer(t, "deadbeef",
+		`[{"name": "HCP Terraform / my-org / ws-a", "target_url": "https://app.terraform.io/app/my-org/workspaces/ws-a/runs/run-aaaa"}]`,
+		`[{"name": "HCP Terraform / my-org / ws-b", "target_url": "https://app.terraform.io/app/my-org/workspaces/ws-b/runs/run-bbbb"},
+		  {"name": "HCP Terraform / my-org / ws-b", "target_url": "https://app.terraform.io/app/my-org/workspaces/ws-b/runs/run-older"}]`)
+	defer ts.Close()
+
+	runID, err := newTestGitLabClient(ts).GetRunIDFromMR(context.Background(), "group/sub/project", 7, "ws-b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runID != "run-bbbb" {
+		t.Errorf("expected run-bbbb, got %q", runID)
+	}
+}
+
+func TestGetRunIDFromMR_MultipleRunsNoFilter(t *testing.T) {
+	ts := newTestGitLabServer(t, "cafebabe",
+		`[{"name": "HCP Terraform / my-org / ws-a", "target_url": "https://app.terraform.io/app/my-org/workspaces/ws-a/runs/run-aaaa"},
+		  {"name": "HCP Terraform / my-org / ws-b", "target_url": "https://app.terraform.io/app/my-org/workspaces/ws-b/runs/run-bbbb"}]`)
+	defer ts.Close()
+
+	_, err := newTestGitLabClient(ts).GetRunIDFromMR(context.Background(), "group/sub/project", 7, "")
+	if err == nil || !strings.Contains(err.Error(), "multiple HCP Terraform runs found in MR !7") {
+		t.Errorf("expected 'multiple HCP Terraform runs found in MR !7' error, got: %v", err)
+	}
+}
+
+func TestGetRunIDFromMR_NoRuns(t *testing.T) {
+	ts := newTestGitLabServer(t, "00000000", `[]`)
+	defer ts.Close()
+
+	_, err := newTestGitLabClient(ts).GetRunIDFromMR(context.Background(), "group/sub/project", 7, "")
+	if err == nil || !strings.Contains(err.Error(), "no HCP Terraform run found in MR !7") {
+		t.Errorf("expected 'no HCP Terraform run found in MR !7' error, got: %v", err)
+	}
+}
+
+func TestGetRunIDFromMR_NotFound(t *testing.T) {
+	ts := newTestGitLabServer(t, "abc123", `[]`)
+	defer ts.Close()
+
+	_, err := newTestGitLabClient(ts).GetRunIDFromMR(context.Background(), "group/sub/project", 99, "")
+	if err == nil || !strings.Contains(err.Error(), "failed to get MR !99") {
+		t.Errorf("expected 'failed to get MR !99' error, got: %v", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+// --- ListRunsFromPR ---
+
+func TestListRunsFromPR(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/pulls/10"):
+			_, _ = fmt.Fprint(w, `{"number": 10, "head": {"sha": "66666666"}}`)
+		case strings.Contains(r.URL.Path, "/commits/66666666/statuses"):
+			_, _ = fmt.Fprint(w, `[
+				{"context": "HCP Terraform / my-org / ws-b", "target_url": "https://app.terraform.io/app/my-org/workspaces/ws-b/runs/run-bbbb"},
+				{"context": "HCP Terraform / my-org / ws-a", "target_url": "https://app.terraform.io/app/my-org/workspaces/ws-a/runs/run-aaaa"},
+				{"context": "HCP Terraform / my-org / ws-a", "target_url": "https://app.terraform.io/app/my-org/workspaces/ws-a/runs/run-older"},
+				{"context": "ci/build", "target_url": "https://ci.example.com/builds/1"}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL + "/")
+	ghClient := github.NewClient(nil)
+	ghClient.BaseURL = baseURL
+	gcw := &GitHubClientWrapper{client: ghClient}
+
+	runs, err := gcw.ListRunsFromPR(context.Background(), "owner", "repo", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []StatusRun{
+		{Context: "HCP Terraform / my-org / ws-a", Workspace: "ws-a", RunID: "run-aaaa", URL: "https://app.terraform.io/app/my-org/workspaces/ws-a/runs/run-aaaa"},
+		{Context: "HCP Terraform / my-org / ws-b", Workspace: "ws-b", RunID: "run-bbbb", URL: "https://app.terraform.io/app/my-org/workspaces/ws-b/runs/run-bbbb"},
+	}
+	if len(runs) != len(want) {
+		t.Fatalf("expected %d runs, got %+v", len(want), runs)
+	}
+	for i := range want {
+		if runs[i] != want[i] {
+			t.Errorf("run %d = %+v, want %+v", i, runs[i], want[i])
+		}
+	}
+}
+
+// --- UpsertPRComment ---
+
+func TestUpsertPRComment(t *testing.T) {
+	const marker = "<!-- hcpt-test -->"
+
+	tests := []struct {
+		name        string
+		user        string
+		comments    string
+		wantCreated bool
+		wantMethod  string
+		wantPath    string
+	}{
+		{
+			name:        "create",
+			user:        `{"login": "hcpt-bot"}`,
+			comments:    `[{"id": 1, "body": "LGTM", "user": {"login": "someone"}}]`,
+			wantCreated: true,
+			wantMethod:  http.MethodPost,
+			wantPath:    "/repos/owner/repo/issues/42/comments",
+		},
+		{
+			name:        "update",
+			user:        `{"login": "hcpt-bot"}`,
+			comments:    `[{"id": 1, "body": "LGTM", "user": {"login": "someone"}}, {"id": 2, "body": "` + marker + `\nold summary", "user": {"login": "hcpt-bot"}}]`,
+			wantCreated: false,
+			wantMethod:  http.MethodPatch,
+			wantPath:    "/repos/owner/repo/issues/comments/2",
+		},
+		{
+			name: "update skips foreign comments with the marker",
+			user: `{"login": "hcpt-bot"}`,
+			comments: `[{"id": 1, "body": "` + marker + `\nspoofed", "user": {"login": "someone"}},` +
+				` {"id": 3, "body": "> ` + marker + `\nquoted", "user": {"login": "hcpt-bot"}},` +
+				` {"id": 2, "body": "` + marker + `\nold summary", "user": {"login": "hcpt-bot"}}]`,
+			wantCreated: false,
+			wantMethod:  http.MethodPatch,
+			wantPath:    "/repos/owner/repo/issues/comments/2",
+		},
+		{
+			name:        "create when only a foreign comment has the marker",
+			user:        `{"login": "hcpt-bot"}`,
+			comments:    `[{"id": 1, "body": "` + marker + `\nspoofed", "user": {"login": "someone"}}]`,
+			wantCreated: true,
+			wantMethod:  http.MethodPost,
+			wantPath:    "/repos/owner/repo/issues/42/comments",
+		},
+		{
+			name:        "installation token updates bot comments",
+			comments:    `[{"id": 1, "body": "` + marker + `\nspoofed", "user": {"login": "someone", "type": "User"}}, {"id": 2, "body": "` + marker + `\nold summary", "user": {"login": "github-actions[bot]", "type": "Bot"}}]`,
+			wantCreated: false,
+			wantMethod:  http.MethodPatch,
+			wantPath:    "/repos/owner/repo/issues/comments/2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotPath, gotBody string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodGet && r.URL.Path == "/user" {
+					if tt.user == "" {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = fmt.Fprint(w, `{"message": "Resource not accessible by integration"}`)
+						return
+					}
+					_, _ = fmt.Fprint(w, tt.user)
+					return
+				}
+				if r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/issues/42/comments" {
+					_, _ = fmt.Fprint(w, tt.comments)
+					return
+				}
+				var req struct {
+					Body string `json:"body"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				gotMethod, gotPath, gotBody = r.Method, r.URL.Path, req.Body
+				_, _ = fmt.Fprint(w, `{"id": 2, "html_url": "https://github.com/owner/repo/pull/42#issuecomment-2"}`)
+			}))
+			defer ts.Close()
+
+			baseURL, _ := url.Parse(ts.URL + "/")
+			ghClient := github.NewClient(nil)
+			ghClient.BaseURL = baseURL
+			gcw := &GitHubClientWrapper{client: ghClient}
+
+			comment, err := gcw.UpsertPRComment(context.Background(), "owner", "repo", 42, marker, "new summary")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if comment.Created != tt.wantCreated {
+				t.Errorf("expected created=%v, got %v", tt.wantCreated, comment.Created)
+			}
+			if comment.URL != "https://github.com/owner/repo/pull/42#issuecomment-2" {
+				t.Errorf("unexpected comment URL: %q", comment.URL)
+			}
+			if gotMethod != tt.wantMethod || gotPath != tt.wantPath {
+				t.Errorf("expected %s %s, got %s %s", tt.wantMethod, tt.wantPath, gotMethod, gotPath)
+			}
+			if gotBody != marker+"\nnew summary" {
+				t.Errorf("unexpected comment body: %q", gotBody)
+			}
+		})
+	}
+}
+
+// --- ListTaskStages / ListPolicyEvaluations ---
+
+// newTestTFEClientWrapper creates a ClientWrapper with a go-tfe client
+// pointing to the given test server URL.
+func newTestTFEClientWrapper(t *testing.T, serverURL string) *ClientWrapper {
+	t.Helper()
+	httpClient := newTestHTTPClient()
+	tfeClient, err := tfe.NewClient(&tfe.Config{Address: serverURL, Token: "test-token", HTTPClient: httpClient})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return &ClientWrapper{client: tfeClient, http: httpClient, address: serverURL, token: "test-token"}
+}
+
+// jsonAPIPage writes a JSON:API list page of the given resources.
+func jsonAPIPage(w http.ResponseWriter, page, totalPages int, resources ...string) {
+	next := "null"
+	if page < totalPages {
+		next = strconv.Itoa(page + 1)
+	}
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	_, _ = fmt.Fprintf(w, `{"data": [%s], "meta": {"pagination": {"current-page": %d, "next-page": %s, "total-pages": %d}}}`,
+		strings.Join(resources, ","), page, next, totalPages)
+}
+
+func newPaginatedTaskStageServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+		if page == 0 {
+			page = 1
+		}
+		switch r.URL.Path {
+		case "/api/v2/ping":
+			w.WriteHeader(http.StatusNoContent)
+		case "/api/v2/runs/run-1/task-stages":
+			stage := fmt.Sprintf(`{"id": "ts-%d", "type": "task-stages", "attributes": {"stage": "post_plan"},
+				"relationships": {"policy-evaluations": {"data": [{"id": "poleval-ts-%d", "type": "policy-evaluations"}]}}}`, page, page)
+			jsonAPIPage(w, page, 2, stage)
+		case "/api/v2/task-stages/ts-1/policy-evaluations", "/api/v2/task-stages/ts-2/policy-evaluations":
+			stageID := strings.Split(r.URL.Path, "/")[4]
+			jsonAPIPage(w, page, 2, fmt.Sprintf(`{"id": "poleval-%s-%d", "type": "policy-evaluations", "attributes": {"status": "passed"}}`, stageID, page))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestListTaskStages_Paginated(t *testing.T) {
+	ts := newPaginatedTaskStageServer(t)
+	defer ts.Close()
+
+	stages, err := newTestTFEClientWrapper(t, ts.URL).ListTaskStages(context.Background(), "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stages) != 2 || stages[0].ID != "ts-1" || stages[1].ID != "ts-2" {
+		t.Fatalf("expected stages from both pages, got %d", len(stages))
+	}
+	if len(stages[1].PolicyEvaluations) != 1 || stages[1].PolicyEvaluations[0].ID != "poleval-ts-2" {
+		t.Errorf("expected policy evaluation IDs in the stage, got %+v", stages[1].PolicyEvaluations)
+	}
+}
+
+func TestListPolicyEvaluations_Paginated(t *testing.T) {
+	ts := newPaginatedTaskStageServer(t)
+	defer ts.Close()
+
+	evaluations, err := newTestTFEClientWrapper(t, ts.URL).ListPolicyEvaluations(context.Background(), "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, pe := range evaluations {
+		got = append(got, pe.ID)
+	}
+	want := "poleval-ts-1-1,poleval-ts-1-2,poleval-ts-2-1,poleval-ts-2-2"
+	if strings.Join(got, ",") != want {
+		t.Errorf("expected %s, got %s", want, strings.Join(got, ","))
+	}
+}
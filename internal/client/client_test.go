@@ -36,6 +36,46 @@ func TestNewClientWrapper_NoToken(t *testing.T) {
 	}
 }
 
+func TestNewClientWrapper_InvalidTimeout(t *testing.T) {
+	for _, value := range []string{"abc", "30", "0s", "-5s"} {
+		t.Run(value, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("token", "test-token")
+			viper.Set("timeout", value)
+
+			_, err := client.NewClientWrapper()
+			if err == nil {
+				t.Fatal("expected error for invalid timeout, got nil")
+			}
+			if want := "invalid timeout"; !contains(err.Error(), want) {
+				t.Errorf("expected error containing %q, got %q", want, err.Error())
+			}
+		})
+	}
+}
+
+func TestNewClientWrapper_NoTokenForProfile(t *testing.T) {
+	viper.Reset()
+	viper.Set("token", "")
+	viper.Set("address", "https://tfe.example.com")
+	viper.Set("profile", "prod-tfe")
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TF_CLI_CONFIG_FILE", filepath.Join(t.TempDir(), "nonexistent"))
+	t.Setenv("TF_TOKEN_tfe_example_com", "")
+
+	_, err := client.NewClientWrapper()
+	if err == nil {
+		t.Fatal("expected error when token is empty, got nil")
+	}
+
+	for _, want := range []string{`profile "prod-tfe"`, "terraform login tfe.example.com"} {
+		if got := err.Error(); !contains(got, want) {
+			t.Errorf("expected error containing %q, got %q", want, got)
+		}
+	}
+}
+
 func TestNewClientWrapper_WithToken(t *testing.T) {
 	viper.Reset()
 	viper.Set("token", "test-token")
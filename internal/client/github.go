@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os/exec"
-	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/google/go-github/v85/github"
@@ -15,84 +17,99 @@ import (
 // It prioritizes 'origin' remote if multiple remotes exist.
 // Returns owner/repo format or an error if not a Git repo or GitHub remote not found.
 func DetectGitHubRepository(ctx context.Context) (string, error) {
-	// Try to get origin remote URL first
-	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", "origin")
-	output, err := cmd.Output()
+	remoteURL, err := gitRemoteURL(ctx)
 	if err != nil {
-		// If origin doesn't exist, try to get any remote
-		cmd = exec.CommandContext(ctx, "git", "remote")
-		remotesOutput, err := cmd.Output()
-		if err != nil {
-			return "", fmt.Errorf("git repository not found in current directory\nPlease specify repository using --repo flag (e.g., --repo owner/repo)")
-		}
-
-		remotes := strings.Fields(string(remotesOutput))
-		if len(remotes) == 0 {
-			return "", fmt.Errorf("no git remote found in current directory\nPlease specify repository using --repo flag (e.g., --repo owner/repo)")
-		}
-
-		// Get URL of the first remote
-		cmd = exec.CommandContext(ctx, "git", "remote", "get-url", remotes[0]) //nolint:gosec // G204: remotes come from git config, not user input
-		output, err = cmd.Output()
-		if err != nil {
-			return "", fmt.Errorf("failed to get git remote URL\nPlease specify repository using --repo flag (e.g., --repo owner/repo)")
-		}
+		return "", err
 	}
-
-	remoteURL := strings.TrimSpace(string(output))
-	return parseGitHubRepository(remoteURL)
+	return parseGitHubRepository(remoteURL, vcsHostname(GitHubHost()))
 }
 
-// parseGitHubRepository parses GitHub repository (owner/repo) from a Git remote URL.
-// Supports both SSH (git@github.com:owner/repo.git) and HTTPS (https://github.com/owner/repo.git) formats.
-func parseGitHubRepository(remoteURL string) (string, error) {
-	// SSH format: git@github.com:owner/repo.git
-	sshPattern := regexp.MustCompile(`^git@github\.com:([^/]+)/(.+?)(\.git)?$`)
-	if matches := sshPattern.FindStringSubmatch(remoteURL); matches != nil {
-		owner := matches[1]
-		repo := strings.TrimSuffix(matches[2], ".git")
-		return fmt.Sprintf("%s/%s", owner, repo), nil
+// defaultGitHubHost is the GitHub host used when github-host is not set.
+const defaultGitHubHost = "github.com"
+
+// GitHubHost returns the configured GitHub host, from the --github-host flag,
+// GH_HOST env, or 'github-host' in the config file. It is a hostname such as
+// "github.example.com" or a URL such as "https://github.example.com".
+func GitHubHost() string {
+	if host := viper.GetString("github-host"); host != "" {
+		return host
 	}
+	return defaultGitHubHost
+}
 
-	// HTTPS format: https://github.com/owner/repo.git or https://github.com/owner/repo
-	httpsPattern := regexp.MustCompile(`^https://github\.com/([^/]+)/(.+?)(\.git)?$`)
-	if matches := httpsPattern.FindStringSubmatch(remoteURL); matches != nil {
-		owner := matches[1]
-		repo := strings.TrimSuffix(matches[2], ".git")
-		return fmt.Sprintf("%s/%s", owner, repo), nil
+// parseGitHubRepository parses GitHub repository (owner/repo) from a Git remote URL on the given host.
+// Supports the scp-like SSH (git@github.com:owner/repo.git), ssh:// (ssh://git@github.com/owner/repo.git),
+// and HTTPS (https://github.com/owner/repo.git) formats, with any SSH user and port.
+func parseGitHubRepository(remoteURL, hostname string) (string, error) {
+	if remoteHost, path, ok := parseRemoteURL(remoteURL); ok && strings.EqualFold(remoteHost, hostname) {
+		parts := strings.Split(path, "/")
+		if len(parts) == 2 {
+			return fmt.Sprintf("%s/%s", parts[0], parts[1]), nil
+		}
 	}
 
-	// Not a GitHub remote
-	return "", fmt.Errorf("git remote is not a GitHub repository: %s\nPlease specify repository using --repo flag (e.g., --repo owner/repo)", remoteURL)
+	// Not a remote on the GitHub host
+	return "", fmt.Errorf("git remote is not a GitHub repository on %s: %s\nPlease specify repository using --repo flag (e.g., --repo owner/repo)", hostname, remoteURL)
 }
 
 // GitHubService provides operations on GitHub repositories.
 type GitHubService interface {
 	GetRunIDFromPR(ctx context.Context, owner, repo string, prNumber int, workspaceName string) (string, error)
+	ListRunsFromPR(ctx context.Context, owner, repo string, prNumber int) ([]StatusRun, error)
+	UpsertPRComment(ctx context.Context, owner, repo string, prNumber int, marker, body string) (*PRComment, error)
+}
+
+// PRComment is a pull request comment created or updated by UpsertPRComment.
+type PRComment struct {
+	URL     string
+	Created bool
 }
 
 // GitHubClientWrapper wraps the go-github client.
 type GitHubClientWrapper struct {
 	client *github.Client
+	// address is the HCP Terraform or Terraform Enterprise address whose run
+	// URLs are looked up in commit statuses and check runs.
+	address string
 }
 
 // NewGitHubClientWrapper creates a new GitHubClientWrapper using token from gh CLI, env, or config.
+// The client talks to GitHub Enterprise Server when github-host is not github.com.
 func NewGitHubClientWrapper() (*GitHubClientWrapper, error) {
-	token := resolveGitHubToken()
+	host := GitHubHost()
+	hostname := vcsHostname(host)
+	token := resolveGitHubToken(hostname)
 	if token == "" {
+		if hostname != defaultGitHubHost {
+			return nil, fmt.Errorf("GitHub token for %s is required: use 'gh auth login --hostname %s', GH_ENTERPRISE_TOKEN env, or 'github-enterprise-token' in config file", hostname, hostname)
+		}
 		return nil, fmt.Errorf("GitHub token is required: use 'gh auth token', GITHUB_TOKEN env, or 'github-token' in config file")
 	}
 
 	client := github.NewClient(nil).WithAuthToken(token)
+	if hostname != defaultGitHubHost {
+		baseURL := host
+		if !strings.Contains(baseURL, "://") {
+			baseURL = "https://" + baseURL
+		}
+		var err error
+		client, err = client.WithEnterpriseURLs(baseURL, baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub host %q: %w", host, err)
+		}
+	}
 
-	return &GitHubClientWrapper{client: client}, nil
+	return &GitHubClientWrapper{client: client, address: viper.GetString("address")}, nil
 }
 
-// resolveGitHubToken resolves GitHub token from multiple sources.
-// Priority: gh CLI > GITHUB_TOKEN env > config file.
-func resolveGitHubToken() string {
+// resolveGitHubToken resolves GitHub token for the host from multiple sources.
+// Priority: gh CLI > GITHUB_TOKEN env > config file. As in gh, GitHub
+// Enterprise Server hosts use GH_ENTERPRISE_TOKEN or GITHUB_ENTERPRISE_TOKEN
+// env and 'github-enterprise-token' in the config file instead, so that a
+// github.com token is never sent to another host.
+func resolveGitHubToken(hostname string) string {
 	// 1. Try gh CLI
-	cmd := exec.CommandContext(context.Background(), "gh", "auth", "token")
+	cmd := exec.CommandContext(context.Background(), "gh", "auth", "token", "--hostname", hostname) //nolint:gosec // G204: hostname comes from config, not arbitrary input
 	if output, err := cmd.Output(); err == nil {
 		token := strings.TrimSpace(string(output))
 		if token != "" {
@@ -100,6 +117,18 @@ func resolveGitHubToken() string {
 		}
 	}
 
+	if hostname != defaultGitHubHost {
+		// 2. Try GH_ENTERPRISE_TOKEN and GITHUB_ENTERPRISE_TOKEN environment variables
+		for _, env := range []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+			if token := viper.GetString(env); token != "" {
+				return token
+			}
+		}
+
+		// 3. Try config file
+		return viper.GetString("github-enterprise-token")
+	}
+
 	// 2. Try GITHUB_TOKEN environment variable
 	if token := viper.GetString("GITHUB_TOKEN"); token != "" {
 		return token
@@ -109,62 +138,149 @@ func resolveGitHubToken() string {
 	return viper.GetString("github-token")
 }
 
-// GetRunIDFromPR retrieves the HCP Terraform run ID from a GitHub PR's commit statuses.
+// GetRunIDFromPR retrieves the HCP Terraform run ID from a GitHub PR's commit
+// statuses and check runs.
 func (c *GitHubClientWrapper) GetRunIDFromPR(ctx context.Context, owner, repo string, prNumber int, workspaceName string) (string, error) {
+	runs, err := c.prStatusRuns(ctx, owner, repo, prNumber)
+	if err != nil {
+		return "", err
+	}
+	return runs.pick(fmt.Sprintf("PR #%d", prNumber), workspaceName)
+}
+
+// ListRunsFromPR lists the latest HCP Terraform run of each commit status and
+// check run on a GitHub PR's head commit, sorted by context.
+func (c *GitHubClientWrapper) ListRunsFromPR(ctx context.Context, owner, repo string, prNumber int) ([]StatusRun, error) {
+	runs, err := c.prStatusRuns(ctx, owner, repo, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	return runs.list(), nil
+}
+
+// prStatusRuns collects the runs linked from the commit statuses and check
+// runs of a GitHub PR's head commit.
+func (c *GitHubClientWrapper) prStatusRuns(ctx context.Context, owner, repo string, prNumber int) (*statusRuns, error) {
 	// Get PR details
 	pr, _, err := c.client.PullRequests.Get(ctx, owner, repo, prNumber)
 	if err != nil {
-		return "", fmt.Errorf("failed to get PR #%d: %w", prNumber, err)
+		return nil, fmt.Errorf("failed to get PR #%d: %w", prNumber, err)
 	}
 
-	// Get commit statuses for the HEAD commit
 	commitSHA := pr.GetHead().GetSHA()
-	statuses, _, err := c.client.Repositories.ListStatuses(ctx, owner, repo, commitSHA, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to get commit statuses for %s: %w", commitSHA, err)
-	}
+	runs := newStatusRuns(c.address)
 
-	// Extract run IDs from statuses (deduplicate by context, keeping the latest)
-	runIDPattern := regexp.MustCompile(`app\.terraform\.io/.+/runs/(run-[A-Za-z0-9]+)`)
-	contextToRunID := make(map[string]string) // context -> run-id (latest)
+	// Get commit statuses for the HEAD commit (newest first)
+	statusOpts := &github.ListOptions{PerPage: 100}
+	for {
+		statuses, resp, err := c.client.Repositories.ListStatuses(ctx, owner, repo, commitSHA, statusOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit statuses for %s: %w", commitSHA, err)
+		}
+		for _, status := range statuses {
+			runs.add(status.GetContext(), status.GetTargetURL())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		statusOpts.Page = resp.NextPage
+	}
 
-	for _, status := range statuses {
-		targetURL := status.GetTargetURL()
-		if matches := runIDPattern.FindStringSubmatch(targetURL); matches != nil {
-			runID := matches[1]
-			context := status.GetContext()
-			// Only keep the first (latest) run-id for each context
-			if _, exists := contextToRunID[context]; !exists {
-				contextToRunID[context] = runID
+	// Get check runs for the HEAD commit, reported by the VCS integration as a
+	// GitHub App. Tokens without access to checks only see commit statuses.
+	checkOpts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		result, resp, err := c.client.Checks.ListCheckRunsForRef(ctx, owner, repo, commitSHA, checkOpts)
+		if err != nil {
+			if isGitHubStatus(err, http.StatusForbidden, http.StatusNotFound) {
+				break
 			}
+			return nil, fmt.Errorf("failed to get check runs for %s: %w", commitSHA, err)
+		}
+		for _, checkRun := range result.CheckRuns {
+			runs.add(checkRun.GetName(), checkRun.GetDetailsURL())
+		}
+		if resp.NextPage == 0 {
+			break
 		}
+		checkOpts.Page = resp.NextPage
 	}
 
-	if len(contextToRunID) == 0 {
-		return "", fmt.Errorf("no HCP Terraform run found in PR #%d", prNumber)
+	return runs, nil
+}
+
+// UpsertPRComment creates a comment on a GitHub PR, or updates the existing
+// comment that starts with marker, so that the PR keeps a single comment. The
+// marker, typically an HTML comment, is written at the top of the comment.
+// Only comments written by the authenticated user are updated, so that a
+// comment quoting the marker is never overwritten.
+func (c *GitHubClientWrapper) UpsertPRComment(ctx context.Context, owner, repo string, prNumber int, marker, body string) (*PRComment, error) {
+	body = marker + "\n" + body
+
+	isOwn, err := c.ownCommentMatcher(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// If workspace name is specified, filter by context
-	if workspaceName != "" {
-		for context, runID := range contextToRunID {
-			if strings.Contains(context, workspaceName) {
-				return runID, nil
+	var existing *github.IssueComment
+	listOpts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for existing == nil {
+		comments, resp, err := c.client.Issues.ListComments(ctx, owner, repo, prNumber, listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments of PR #%d: %w", prNumber, err)
+		}
+		for _, comment := range comments {
+			if strings.HasPrefix(comment.GetBody(), marker) && isOwn(comment) {
+				existing = comment
+				break
 			}
 		}
-		return "", fmt.Errorf("no HCP Terraform run found for workspace '%s' in PR #%d", workspaceName, prNumber)
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+
+	if existing != nil {
+		comment, _, err := c.client.Issues.EditComment(ctx, owner, repo, existing.GetID(), &github.IssueComment{Body: &body})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update comment on PR #%d: %w", prNumber, err)
+		}
+		return &PRComment{URL: comment.GetHTMLURL()}, nil
 	}
 
-	// If only one run found, return it
-	if len(contextToRunID) == 1 {
-		for _, runID := range contextToRunID {
-			return runID, nil
+	comment, _, err := c.client.Issues.CreateComment(ctx, owner, repo, prNumber, &github.IssueComment{Body: &body})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment on PR #%d: %w", prNumber, err)
+	}
+	return &PRComment{URL: comment.GetHTMLURL(), Created: true}, nil
+}
+
+// ownCommentMatcher returns a function reporting whether a comment was
+// written by the authenticated user. Installation tokens, such as the
+// GITHUB_TOKEN of GitHub Actions, cannot read the authenticated user and
+// match the comments written by a bot instead.
+func (c *GitHubClientWrapper) ownCommentMatcher(ctx context.Context) (func(*github.IssueComment) bool, error) {
+	user, _, err := c.client.Users.Get(ctx, "")
+	if err != nil {
+		if isGitHubStatus(err, http.StatusForbidden) {
+			return func(comment *github.IssueComment) bool {
+				return comment.GetUser().GetType() == "Bot"
+			}, nil
 		}
+		return nil, fmt.Errorf("failed to get authenticated GitHub user: %w", err)
 	}
+	return func(comment *github.IssueComment) bool {
+		return comment.GetUser().GetLogin() == user.GetLogin()
+	}, nil
+}
 
-	// Multiple runs found, list contexts for user guidance
-	contexts := make([]string, 0, len(contextToRunID))
-	for context := range contextToRunID {
-		contexts = append(contexts, fmt.Sprintf("  - %s", context))
+// isGitHubStatus reports whether err is a GitHub API error response with one
+// of the given HTTP status codes.
+func isGitHubStatus(err error, codes ...int) bool {
+	var respErr *github.ErrorResponse
+	if !errors.As(err, &respErr) || respErr.Response == nil {
+		return false
 	}
-	return "", fmt.Errorf("multiple HCP Terraform runs found in PR #%d:\n%s\nUse --workspace/-w to specify which one", prNumber, strings.Join(contexts, "\n"))
+	return slices.Contains(codes, respErr.Response.StatusCode)
 }
@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 // mockGitHubService is a mock implementation of GitHubService for testing.
@@ -18,6 +20,14 @@ func (m *mockGitHubService) GetRunIDFromPR(_ context.Context, _, _ string, _ int
 	return m.runID, nil
 }
 
+func (m *mockGitHubService) ListRunsFromPR(_ context.Context, _, _ string, _ int) ([]StatusRun, error) {
+	return nil, m.err
+}
+
+func (m *mockGitHubService) UpsertPRComment(_ context.Context, _, _ string, _ int, _, _ string) (*PRComment, error) {
+	return nil, m.err
+}
+
 // TestGetRunIDFromPR tests the basic functionality of GetRunIDFromPR.
 // Note: This test requires actual GitHub API access, so we only test the mock here.
 func TestGetRunIDFromPR(t *testing.T) {
@@ -116,6 +126,18 @@ func TestParseGitHubRepository(t *testing.T) {
 			expectedRepo: "",
 			expectError:  true,
 		},
+		{
+			name:         "ssh URL format",
+			remoteURL:    "ssh://git@github.com/owner/repo.git",
+			expectedRepo: "owner/repo",
+			expectError:  false,
+		},
+		{
+			name:         "ssh URL with port",
+			remoteURL:    "ssh://git@github.com:22/owner/repo.git",
+			expectedRepo: "owner/repo",
+			expectError:  false,
+		},
 		{
 			name:         "invalid format",
 			remoteURL:    "not-a-valid-url",
@@ -126,7 +148,7 @@ func TestParseGitHubRepository(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			repo, err := parseGitHubRepository(tt.remoteURL)
+			repo, err := parseGitHubRepository(tt.remoteURL, "github.com")
 
 			if tt.expectError {
 				if err == nil {
@@ -143,3 +165,84 @@ func TestParseGitHubRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGitHubRepository_EnterpriseHost(t *testing.T) {
+	tests := []struct {
+		remoteURL    string
+		expectedRepo string
+	}{
+		{"git@github.example.com:owner/repo.git", "owner/repo"},
+		{"org-123@github.example.com:owner/repo.git", "owner/repo"},
+		{"ssh://git@github.example.com/owner/repo.git", "owner/repo"},
+		{"ssh://deploy@github.example.com:2222/owner/repo", "owner/repo"},
+		{"https://github.example.com/owner/repo.git", "owner/repo"},
+		{"https://user@github.example.com:8443/owner/repo", "owner/repo"},
+		{"git@github.com:owner/repo.git", ""},
+		{"https://github.com/owner/repo.git", ""},
+		{"https://github.example.com/owner", ""},
+		{"ftp://github.example.com/owner/repo.git", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remoteURL, func(t *testing.T) {
+			repo, err := parseGitHubRepository(tt.remoteURL, "github.example.com")
+			if tt.expectedRepo == "" {
+				if err == nil {
+					t.Errorf("expected error but got %q", repo)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repo != tt.expectedRepo {
+				t.Errorf("expected repo %q, got %q", tt.expectedRepo, repo)
+			}
+		})
+	}
+}
+
+func TestGitHubHost(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	if got := GitHubHost(); got != "github.com" {
+		t.Errorf("expected default host github.com, got %q", got)
+	}
+
+	viper.Set("github-host", "https://github.example.com")
+	if got := GitHubHost(); got != "https://github.example.com" {
+		t.Errorf("expected configured host, got %q", got)
+	}
+	if got := vcsHostname(GitHubHost()); got != "github.example.com" {
+		t.Errorf("expected hostname github.example.com, got %q", got)
+	}
+}
+
+func TestRunURLPattern(t *testing.T) {
+	tests := []struct {
+		address string
+		url     string
+		want    string
+	}{
+		{"", "https://app.terraform.io/app/my-org/workspaces/ws/runs/run-abc123", "run-abc123"},
+		{"https://app.terraform.io", "https://app.terraform.io/app/my-org/workspaces/ws/runs/run-abc123", "run-abc123"},
+		{"https://tfe.example.com", "https://tfe.example.com/app/my-org/workspaces/ws/runs/run-abc123", "run-abc123"},
+		{"https://tfe.example.com:8443", "https://tfe.example.com:8443/app/my-org/workspaces/ws/runs/run-abc123", "run-abc123"},
+		{"https://tfe.example.com", "https://app.terraform.io/app/my-org/workspaces/ws/runs/run-abc123", ""},
+		{"https://tfe.example.com", "https://tfeXexample.com/app/my-org/workspaces/ws/runs/run-abc123", ""},
+		{"", "https://ci.example.com/builds/1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address+" "+tt.url, func(t *testing.T) {
+			got := ""
+			if matches := runURLPattern(tt.address).FindStringSubmatch(tt.url); matches != nil {
+				got = matches[2]
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
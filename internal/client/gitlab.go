@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// defaultGitLabHost is the GitLab host used when gitlab-host is not set.
+const defaultGitLabHost = "gitlab.com"
+
+// GitLabHost returns the configured GitLab host, from the --gitlab-host flag,
+// GITLAB_HOST env, or 'gitlab-host' in the config file. It is a hostname such
+// as "gitlab.example.com" or a URL such as "https://gitlab.example.com".
+func GitLabHost() string {
+	if host := viper.GetString("gitlab-host"); host != "" {
+		return host
+	}
+	return defaultGitLabHost
+}
+
+// DetectGitLabProject detects GitLab project path (group/project) from current directory's Git remote.
+// Projects in subgroups are returned with their full path (group/subgroup/project).
+func DetectGitLabProject(ctx context.Context) (string, error) {
+	remoteURL, err := gitRemoteURL(ctx)
+	if err != nil {
+		return "", err
+	}
+	return parseGitLabProject(remoteURL, vcsHostname(GitLabHost()))
+}
+
+// parseGitLabProject parses GitLab project path from a Git remote URL on the given host.
+func parseGitLabProject(remoteURL, hostname string) (string, error) {
+	if remoteHost, path, ok := parseRemoteURL(remoteURL); ok && strings.EqualFold(remoteHost, hostname) {
+		if strings.Count(path, "/") >= 1 {
+			return path, nil
+		}
+	}
+
+	// Not a remote on the GitLab host
+	return "", fmt.Errorf("git remote is not a GitLab project on %s: %s\nPlease specify project using --repo flag (e.g., --repo group/project)", hostname, remoteURL)
+}
+
+// GitLabService provides operations on GitLab projects.
+type GitLabService interface {
+	GetRunIDFromMR(ctx context.Context, project string, mrIID int, workspaceName string) (string, error)
+}
+
+// GitLabClientWrapper calls the GitLab REST API.
+type GitLabClientWrapper struct {
+	http    *http.Client
+	baseURL string // e.g. https://gitlab.com/api/v4
+	token   string
+	// address is the HCP Terraform or Terraform Enterprise address whose run
+	// URLs are looked up in commit statuses.
+	address string
+}
+
+// NewGitLabClientWrapper creates a new GitLabClientWrapper using token from glab CLI, env, or config.
+func NewGitLabClientWrapper() (*GitLabClientWrapper, error) {
+	host := GitLabHost()
+	hostname := vcsHostname(host)
+	token := resolveGitLabToken(hostname)
+	if token == "" {
+		if hostname != defaultGitLabHost {
+			return nil, fmt.Errorf("GitLab token for %s is required: use 'glab auth login --hostname %s', GITLAB_SELF_MANAGED_TOKEN env, or 'gitlab-self-managed-token' in config file", hostname, hostname)
+		}
+		return nil, fmt.Errorf("GitLab token is required: use 'glab auth login', GITLAB_TOKEN env, or 'gitlab-token' in config file")
+	}
+
+	timeout, err := requestTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := host
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+
+	return &GitLabClientWrapper{
+		http:    newHTTPClient(timeout),
+		baseURL: strings.TrimRight(baseURL, "/") + "/api/v4",
+		token:   token,
+		address: viper.GetString("address"),
+	}, nil
+}
+
+// resolveGitLabToken resolves GitLab token for the host from multiple sources.
+// Priority: glab CLI > GITLAB_TOKEN env > config file. Self-managed hosts use
+// GITLAB_SELF_MANAGED_TOKEN env and 'gitlab-self-managed-token' in the config
+// file instead, so that a gitlab.com token is never sent to another host.
+func resolveGitLabToken(hostname string) string {
+	// 1. Try glab CLI
+	cmd := exec.CommandContext(context.Background(), "glab", "config", "get", "token", "--host", hostname) //nolint:gosec // G204: hostname comes from config, not arbitrary input
+	if output, err := cmd.Output(); err == nil {
+		token := strings.TrimSpace(string(output))
+		if token != "" {
+			return token
+		}
+	}
+
+	if hostname != defaultGitLabHost {
+		// 2. Try GITLAB_SELF_MANAGED_TOKEN environment variable
+		if token := viper.GetString("GITLAB_SELF_MANAGED_TOKEN"); token != "" {
+			return token
+		}
+
+		// 3. Try config file
+		return viper.GetString("gitlab-self-managed-token")
+	}
+
+	// 2. Try GITLAB_TOKEN environment variable
+	if token := viper.GetString("GITLAB_TOKEN"); token != "" {
+		return token
+	}
+
+	// 3. Try config file
+	return viper.GetString("gitlab-token")
+}
+
+// GetRunIDFromMR retrieves the HCP Terraform run ID from a GitLab merge request's commit statuses.
+func (c *GitLabClientWrapper) GetRunIDFromMR(ctx context.Context, project string, mrIID int, workspaceName string) (string, error) {
+	projectPath := "projects/" + url.PathEscape(project)
+
+	var mr struct {
+		SHA string `json:"sha"`
+	}
+	if _, err := c.get(ctx, fmt.Sprintf("%s/merge_requests/%d", projectPath, mrIID), nil, &mr); err != nil {
+		return "", fmt.Errorf("failed to get MR !%d: %w", mrIID, err)
+	}
+
+	// Get commit statuses for the HEAD commit (newest first)
+	runs := newStatusRuns(c.address)
+	params := url.Values{}
+	params.Set("per_page", "100")
+	params.Set("sort", "desc")
+	for page := "1"; page != ""; {
+		params.Set("page", page)
+		var statuses []struct {
+			Name      string `json:"name"`
+			TargetURL string `json:"target_url"`
+		}
+		resp, err := c.get(ctx, projectPath+"/repository/commits/"+url.PathEscape(mr.SHA)+"/statuses", params, &statuses)
+		if err != nil {
+			return "", fmt.Errorf("failed to get commit statuses for %s: %w", mr.SHA, err)
+		}
+		for _, status := range statuses {
+			runs.add(status.Name, status.TargetURL)
+		}
+		page = resp.Header.Get("X-Next-Page")
+	}
+
+	return runs.pick(fmt.Sprintf("MR !%d", mrIID), workspaceName)
+}
+
+// get sends a GET request to the GitLab API and decodes the JSON response into v.
+func (c *GitLabClientWrapper) get(ctx context.Context, path string, params url.Values, v any) (*http.Response, error) {
+	apiURL := c.baseURL + "/" + path
+	if len(params) > 0 {
+		apiURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{Endpoint: "GitLab", StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return resp, nil
+}
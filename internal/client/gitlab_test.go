@@ -0,0 +1,59 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestParseGitLabProject(t *testing.T) {
+	tests := []struct {
+		name            string
+		remoteURL       string
+		hostname        string
+		expectedProject string
+		expectError     bool
+	}{
+		{"SSH format", "git@gitlab.com:group/project.git", "gitlab.com", "group/project", false},
+		{"SSH format with subgroup", "git@gitlab.com:group/sub/project.git", "gitlab.com", "group/sub/project", false},
+		{"ssh URL with port", "ssh://git@gitlab.example.com:2222/group/project.git", "gitlab.example.com", "group/project", false},
+		{"HTTPS format", "https://gitlab.com/group/project", "gitlab.com", "group/project", false},
+		{"HTTPS format with subgroup", "https://gitlab.example.com/group/sub/project.git", "gitlab.example.com", "group/sub/project", false},
+		{"other host", "git@gitlab.com:group/project.git", "gitlab.example.com", "", true},
+		{"GitHub remote", "https://github.com/owner/repo.git", "gitlab.com", "", true},
+		{"missing project", "https://gitlab.com/group", "gitlab.com", "", true},
+		{"invalid format", "not-a-valid-url", "gitlab.com", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := parseGitLabProject(tt.remoteURL, tt.hostname)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error but got %q", project)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if project != tt.expectedProject {
+				t.Errorf("expected project %q, got %q", tt.expectedProject, project)
+			}
+		})
+	}
+}
+
+func TestGitLabHost(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	if got := GitLabHost(); got != "gitlab.com" {
+		t.Errorf("expected default host gitlab.com, got %q", got)
+	}
+
+	viper.Set("gitlab-host", "gitlab.example.com")
+	if got := GitLabHost(); got != "gitlab.example.com" {
+		t.Errorf("expected configured host, got %q", got)
+	}
+}
@@ -1,28 +1,50 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
-// findTerraformToken searches for a Terraform CLI token for the given hostname.
-// It checks in order: TF_TOKEN_<hostname> env var, credentials.tfrc.json, .terraformrc.
+// credentialsHelperTimeout bounds how long a credentials helper may run.
+const credentialsHelperTimeout = 10 * time.Second
+
+// findTerraformToken searches for a Terraform CLI token for the given hostname,
+// following the Terraform CLI: the TF_TOKEN_<hostname> env var, then stored
+// credentials, then the credentials_helper configured in .terraformrc. As in
+// Terraform, a configured credentials helper replaces credentials.tfrc.json,
+// while credentials blocks in .terraformrc still take precedence over it.
 func findTerraformToken(hostname string) string {
 	if token := findTokenFromEnv(hostname); token != "" {
 		return token
 	}
-	if token := findTokenFromCredentialsJSON(hostname); token != "" {
-		return token
+
+	config := readTerraformRC()
+	hasHelper := config != nil && len(config.CredentialsHelpers) > 0
+	if !hasHelper {
+		if token := findTokenFromCredentialsJSON(hostname); token != "" {
+			return token
+		}
 	}
-	if token := findTokenFromTerraformRC(hostname); token != "" {
+	if token := findTokenFromTerraformRC(config, hostname); token != "" {
 		return token
 	}
+	if hasHelper {
+		// Terraform allows at most one credentials_helper block.
+		return findTokenFromCredentialsHelper(config.CredentialsHelpers[0], hostname)
+	}
 	return ""
 }
 
@@ -68,8 +90,9 @@ func findTokenFromCredentialsJSON(hostname string) string {
 
 // terraformRCConfig represents the HCL structure of .terraformrc.
 type terraformRCConfig struct {
-	Credentials []terraformRCCredential `hcl:"credentials,block"`
-	Remain      hcl.Body                `hcl:",remain"`
+	Credentials        []terraformRCCredential        `hcl:"credentials,block"`
+	CredentialsHelpers []terraformRCCredentialsHelper `hcl:"credentials_helper,block"`
+	Remain             hcl.Body                       `hcl:",remain"`
 }
 
 type terraformRCCredential struct {
@@ -77,22 +100,37 @@ type terraformRCCredential struct {
 	Token string `hcl:"token"`
 }
 
-// findTokenFromTerraformRC reads token from .terraformrc (HCL format).
-func findTokenFromTerraformRC(hostname string) string {
+type terraformRCCredentialsHelper struct {
+	Name string   `hcl:"name,label"`
+	Args []string `hcl:"args,optional"`
+}
+
+// readTerraformRC parses .terraformrc (HCL format). It returns nil if the file
+// is missing or invalid.
+func readTerraformRC() *terraformRCConfig {
 	path := terraformRCPath()
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed from home dir, not user-controlled input
 	if err != nil {
-		return ""
+		return nil
 	}
 
 	file, diags := hclsyntax.ParseConfig(data, path, hcl.Pos{Line: 1, Column: 1})
 	if diags.HasErrors() {
-		return ""
+		return nil
 	}
 
 	var config terraformRCConfig
 	diags = gohcl.DecodeBody(file.Body, nil, &config)
 	if diags.HasErrors() {
+		return nil
+	}
+	return &config
+}
+
+// findTokenFromTerraformRC returns the token of the credentials block for the
+// hostname in the parsed .terraformrc.
+func findTokenFromTerraformRC(config *terraformRCConfig, hostname string) string {
+	if config == nil {
 		return ""
 	}
 
@@ -104,6 +142,109 @@ func findTokenFromTerraformRC(hostname string) string {
 	return ""
 }
 
+// findTokenFromCredentialsHelper obtains a token from a credentials_helper
+// configured in .terraformrc, following the Terraform credentials helper
+// protocol: the helper is invoked as "terraform-credentials-<name> [args...] get <hostname>"
+// and prints a JSON object with a "token" property. Any failure yields an empty string.
+func findTokenFromCredentialsHelper(helper terraformRCCredentialsHelper, hostname string) string {
+	path := findCredentialsHelper(helper.Name)
+	if path == "" {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialsHelperTimeout)
+	defer cancel()
+
+	args := append(append([]string{}, helper.Args...), "get", hostname)
+	cmd := exec.CommandContext(ctx, path, args...) //nolint:gosec // G204: helper path and args come from the user's Terraform CLI config
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return ""
+	}
+	return result.Token
+}
+
+// findCredentialsHelper locates the terraform-credentials-<name> executable
+// in the Terraform plugin directories. Versioned binaries
+// (terraform-credentials-<name>_v1.2.3) are accepted; like the Terraform CLI,
+// the highest version wins.
+func findCredentialsHelper(name string) string {
+	base := "terraform-credentials-" + name
+	for _, dir := range terraformPluginDirs() {
+		candidates := []string{filepath.Join(dir, base)}
+		if matches, err := filepath.Glob(filepath.Join(dir, base+"_*")); err == nil {
+			sort.SliceStable(matches, func(i, j int) bool {
+				return compareHelperVersions(helperVersion(matches[i], base), helperVersion(matches[j], base)) > 0
+			})
+			candidates = append(candidates, matches...)
+		}
+		for _, p := range candidates {
+			if info, err := os.Stat(p); err == nil && !info.IsDir() {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// helperVersion returns the numeric parts of the version of a versioned
+// credentials helper path, e.g. [1 10 0] for terraform-credentials-vault_v1.10.0,
+// or nil if the version is not numeric.
+func helperVersion(path, base string) []int {
+	version := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), base+"_"), ".exe")
+	version = strings.TrimPrefix(version, "v")
+	parts := strings.Split(version, ".")
+	nums := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil
+		}
+		nums = append(nums, n)
+	}
+	return nums
+}
+
+// compareHelperVersions compares two helper versions part by part. Versions
+// that are not numeric sort below every numeric version.
+func compareHelperVersions(a, b []int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return slices.Compare(a, b)
+}
+
+// terraformPluginDirs returns the directories searched for credentials helpers,
+// mirroring the Terraform CLI: the user plugin directory and its OS/arch subdirectory.
+func terraformPluginDirs() []string {
+	var roots []string
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			roots = append(roots, filepath.Join(appData, "terraform.d", "plugins"))
+		}
+	} else if home, err := os.UserHomeDir(); err == nil {
+		roots = append(roots, filepath.Join(home, ".terraform.d", "plugins"))
+	}
+
+	dirs := make([]string, 0, len(roots)*2)
+	for _, root := range roots {
+		dirs = append(dirs, root, filepath.Join(root, runtime.GOOS+"_"+runtime.GOARCH))
+	}
+	return dirs
+}
+
 // terraformRCPath returns the path to .terraformrc.
 // If TF_CLI_CONFIG_FILE is set, it is used instead of the default.
 func terraformRCPath() string {
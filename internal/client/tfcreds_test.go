@@ -3,6 +3,7 @@ package client
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -108,7 +109,7 @@ func TestFindTokenFromTerraformRC(t *testing.T) {
 		}
 		t.Setenv("TF_CLI_CONFIG_FILE", tmpFile)
 
-		got := findTokenFromTerraformRC("app.terraform.io")
+		got := findTokenFromTerraformRC(readTerraformRC(), "app.terraform.io")
 		if got != "hcl-token-789" {
 			t.Errorf("expected %q, got %q", "hcl-token-789", got)
 		}
@@ -129,7 +130,7 @@ credentials "tfe.example.com" {
 		}
 		t.Setenv("TF_CLI_CONFIG_FILE", tmpFile)
 
-		got := findTokenFromTerraformRC("tfe.example.com")
+		got := findTokenFromTerraformRC(readTerraformRC(), "tfe.example.com")
 		if got != "token-b" {
 			t.Errorf("expected %q, got %q", "token-b", got)
 		}
@@ -146,7 +147,7 @@ credentials "tfe.example.com" {
 		}
 		t.Setenv("TF_CLI_CONFIG_FILE", tmpFile)
 
-		got := findTokenFromTerraformRC("other.host.com")
+		got := findTokenFromTerraformRC(readTerraformRC(), "other.host.com")
 		if got != "" {
 			t.Errorf("expected empty string, got %q", got)
 		}
@@ -155,13 +156,164 @@ credentials "tfe.example.com" {
 	t.Run("file not found", func(t *testing.T) {
 		t.Setenv("TF_CLI_CONFIG_FILE", "/nonexistent/.terraformrc")
 
-		got := findTokenFromTerraformRC("app.terraform.io")
+		got := findTokenFromTerraformRC(readTerraformRC(), "app.terraform.io")
 		if got != "" {
 			t.Errorf("expected empty string, got %q", got)
 		}
 	})
 }
 
+// writeCredentialsHelper installs a shell-script credentials helper named
+// terraform-credentials-<name> in the plugin directory under home.
+func writeCredentialsHelper(t *testing.T, home, name, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("credentials helper tests use shell scripts")
+	}
+	dir := filepath.Join(home, ".terraform.d", "plugins")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "terraform-credentials-"+name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o700); err != nil { //nolint:gosec // G306: helper must be executable
+		t.Fatal(err)
+	}
+}
+
+func TestFindTokenFromCredentialsHelper(t *testing.T) {
+	t.Setenv("TF_TOKEN_app_terraform_io", "")
+	t.Setenv("TF_TOKEN_tfe_example_com", "")
+
+	writeRC := func(t *testing.T, content string) {
+		t.Helper()
+		tmpFile := filepath.Join(t.TempDir(), ".terraformrc")
+		if err := os.WriteFile(tmpFile, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("TF_CLI_CONFIG_FILE", tmpFile)
+	}
+
+	t.Run("token found with args", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		writeCredentialsHelper(t, home, "vault", `if [ "$1" = "--mount" ] && [ "$2" = "secret" ] && [ "$3" = "get" ]; then
+  echo "{\"token\": \"helper-token-for-$4\"}"
+  exit 0
+fi
+exit 1
+`)
+		writeRC(t, `credentials_helper "vault" {
+  args = ["--mount", "secret"]
+}
+`)
+
+		got := findTerraformToken("tfe.example.com")
+		if got != "helper-token-for-tfe.example.com" {
+			t.Errorf("expected %q, got %q", "helper-token-for-tfe.example.com", got)
+		}
+	})
+
+	t.Run("versioned helper binary", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		writeCredentialsHelper(t, home, "vault_v1.0.0", `echo '{"token": "versioned-token"}'`)
+		writeRC(t, `credentials_helper "vault" {}
+`)
+
+		got := findTerraformToken("app.terraform.io")
+		if got != "versioned-token" {
+			t.Errorf("expected %q, got %q", "versioned-token", got)
+		}
+	})
+
+	t.Run("highest helper version wins", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		writeCredentialsHelper(t, home, "vault_v1.9.0", `echo '{"token": "old-token"}'`)
+		writeCredentialsHelper(t, home, "vault_v1.10.0", `echo '{"token": "new-token"}'`)
+		writeRC(t, `credentials_helper "vault" {}
+`)
+
+		got := findTerraformToken("app.terraform.io")
+		if got != "new-token" {
+			t.Errorf("expected %q, got %q", "new-token", got)
+		}
+	})
+
+	t.Run("no credentials for host", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		writeCredentialsHelper(t, home, "vault", `echo '{}'`)
+		writeRC(t, `credentials_helper "vault" {}
+`)
+
+		if got := findTerraformToken("app.terraform.io"); got != "" {
+			t.Errorf("expected empty string, got %q", got)
+		}
+	})
+
+	t.Run("helper fails", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		writeCredentialsHelper(t, home, "vault", `echo "vault sealed" >&2
+exit 1
+`)
+		writeRC(t, `credentials_helper "vault" {}
+`)
+
+		if got := findTerraformToken("app.terraform.io"); got != "" {
+			t.Errorf("expected empty string, got %q", got)
+		}
+	})
+
+	t.Run("helper not installed", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		writeRC(t, `credentials_helper "vault" {}
+`)
+
+		if got := findTerraformToken("app.terraform.io"); got != "" {
+			t.Errorf("expected empty string, got %q", got)
+		}
+	})
+
+	t.Run("helper replaces credentials.tfrc.json", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		t.Setenv("TF_TOKEN_app_terraform_io", "")
+		writeCredentialsHelper(t, home, "vault", `echo '{"token": "helper-token"}'`)
+		writeRC(t, `credentials_helper "vault" {}
+`)
+		if err := os.WriteFile(filepath.Join(home, ".terraform.d", "credentials.tfrc.json"),
+			[]byte(`{"credentials": {"app.terraform.io": {"token": "json-token"}}}`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := findTerraformToken("app.terraform.io"); got != "helper-token" {
+			t.Errorf("expected %q, got %q", "helper-token", got)
+		}
+	})
+
+	t.Run("credentials block takes priority over helper", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		t.Setenv("TF_TOKEN_app_terraform_io", "")
+		writeCredentialsHelper(t, home, "vault", `echo '{"token": "helper-token"}'`)
+		writeRC(t, `credentials "app.terraform.io" {
+  token = "hcl-token"
+}
+
+credentials_helper "vault" {}
+`)
+
+		if got := findTerraformToken("app.terraform.io"); got != "hcl-token" {
+			t.Errorf("expected %q, got %q", "hcl-token", got)
+		}
+		if got := findTerraformToken("tfe.example.com"); got != "helper-token" {
+			t.Errorf("expected %q, got %q", "helper-token", got)
+		}
+	})
+}
+
 func TestFindTerraformToken_Priority(t *testing.T) {
 	// Set up credentials.tfrc.json
 	home := t.TempDir()
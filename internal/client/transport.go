@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRequestTimeout is the per-request timeout used when none is configured.
+	DefaultRequestTimeout = 30 * time.Second
+
+	// defaultMaxRetries is the number of retries for rate-limited or failed requests.
+	defaultMaxRetries = 3
+)
+
+// Typed errors for HCP Terraform API responses. Errors returned by the
+// hand-rolled JSON:API endpoints wrap one of these, so callers can check
+// them with errors.Is.
+var (
+	ErrNotFound     = errors.New("resource not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// APIError is returned when an HCP Terraform endpoint responds with an
+// unexpected HTTP status code.
+type APIError struct {
+	Endpoint   string
+	StatusCode int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s endpoint returned HTTP %d", e.Endpoint, e.StatusCode)
+}
+
+// Is reports whether the status code corresponds to one of the typed errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	default:
+		return false
+	}
+}
+
+// retryTransport is an http.RoundTripper that retries requests answered with
+// HTTP 429 (any method) or 5xx (idempotent methods only), waiting according
+// to the Retry-After header or exponential backoff. Each attempt is bounded
+// by timeout, which also covers reading the response body.
+type retryTransport struct {
+	base       http.RoundTripper
+	timeout    time.Duration
+	maxRetries int
+	backoff    func(retryAfter string, attempt int) time.Duration
+}
+
+// newHTTPClient returns an http.Client using retryTransport with the given
+// per-request timeout. A zero timeout disables the timeout.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			timeout:    timeout,
+			maxRetries: defaultMaxRetries,
+			backoff:    retryAfterDuration,
+		},
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq, cancel, err := t.prepareAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		if attempt >= t.maxRetries || !shouldRetry(req, resp.StatusCode) || !canReplay(req) {
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		wait := t.backoff(resp.Header.Get("Retry-After"), attempt)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		cancel()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// prepareAttempt returns the request to send for the given attempt, bounded
+// by the per-request timeout, with a rewound body on retries.
+func (t *retryTransport) prepareAttempt(req *http.Request, attempt int) (*http.Request, context.CancelFunc, error) {
+	ctx := req.Context()
+	cancel := context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+	}
+
+	attemptReq := req.WithContext(ctx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		attemptReq.Body = body
+	}
+	return attemptReq, cancel, nil
+}
+
+// shouldRetry reports whether a response status warrants another attempt.
+// Server errors are only retried for idempotent methods, since the request
+// may already have been processed.
+func shouldRetry(req *http.Request, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if statusCode >= http.StatusInternalServerError {
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+	return false
+}
+
+// canReplay reports whether the request body can be sent again.
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// cancelOnClose releases the per-attempt context once the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// getJSONAPI sends a GET request to an HCP Terraform API path (relative to
+// /api/v2/) and returns the response body. Non-200 responses are returned as
+// *APIError, named after endpoint.
+func (c *ClientWrapper) getJSONAPI(ctx context.Context, endpoint, path string, params url.Values) ([]byte, error) {
+	address := c.address
+	if address == "" {
+		address = "https://app.terraform.io"
+	}
+
+	apiURL := strings.TrimRight(address, "/") + "/api/v2/" + path
+	if len(params) > 0 {
+		apiURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{Endpoint: endpoint, StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", endpoint, err)
+	}
+	return body, nil
+}
+
+// logChunkSize is the maximum number of bytes requested per log chunk.
+const logChunkSize = 64 * 1024
+
+// ReadLogChunk reads the next chunk of a plan or apply log starting at offset.
+// logReadURL is the pre-signed log-read-url of the plan or apply, so no API
+// token is sent. An empty chunk means no new output is available yet.
+func (c *ClientWrapper) ReadLogChunk(ctx context.Context, logReadURL string, offset int64) ([]byte, error) {
+	u, err := url.Parse(logReadURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("limit", strconv.Itoa(logChunkSize))
+	q.Set("offset", strconv.FormatInt(offset, 10))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch logs: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{Endpoint: "logs", StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs response: %w", err)
+	}
+	return body, nil
+}
+
+// defaultHTTPClient is used by wrappers constructed without an HTTP client.
+var defaultHTTPClient = newHTTPClient(DefaultRequestTimeout)
+
+// httpClient returns the wrapper's HTTP client, or the default one.
+func (c *ClientWrapper) httpClient() *http.Client {
+	if c.http == nil {
+		return defaultHTTPClient
+	}
+	return c.http
+}
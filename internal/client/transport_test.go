@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport_RetriesRateLimitThenSucceeds(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, "ok")
+	}))
+	defer ts.Close()
+
+	var waits []string
+	client := &http.Client{Transport: &retryTransport{
+		base:       http.DefaultTransport,
+		maxRetries: 3,
+		backoff: func(retryAfter string, _ int) time.Duration {
+			waits = append(waits, retryAfter)
+			return 0
+		},
+	}}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected HTTP 200, got %d", resp.StatusCode)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+	if len(waits) != 2 || waits[0] != "1" {
+		t.Errorf("expected backoff to receive Retry-After header twice, got %v", waits)
+	}
+}
+
+func TestRetryTransport_RetriesServerErrorForGet(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL, nil)
+	resp, err := newTestHTTPClient().Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected HTTP 200, got %d", resp.StatusCode)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestRetryTransport_DoesNotRetryServerErrorForPost(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, ts.URL, strings.NewReader("{}"))
+	resp, err := newTestHTTPClient().Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected HTTP 500, got %d", resp.StatusCode)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestRetryTransport_ReplaysBodyOnRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	var lastBody string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		lastBody = string(b)
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, ts.URL, strings.NewReader(`{"a":1}`))
+	resp, err := newTestHTTPClient().Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("expected HTTP 201, got %d", resp.StatusCode)
+	}
+	if lastBody != `{"a":1}` {
+		t.Errorf("expected body to be replayed, got %q", lastBody)
+	}
+}
+
+func TestRetryTransport_Timeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		base:    http.DefaultTransport,
+		timeout: 50 * time.Millisecond,
+		backoff: retryAfterDuration,
+	}}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL, nil)
+	resp, err := client.Do(req)
+	if err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected timeout error, got nil")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded error, got: %v", err)
+	}
+}
+
+func TestGetJSONAPI_TypedErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusTooManyRequests, ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer ts.Close()
+
+			cw := newTestClientWrapper(ts.URL)
+			_, err := cw.getJSONAPI(context.Background(), "test", "things", nil)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got: %v", tt.want, err)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, apiErr.StatusCode)
+			}
+		})
+	}
+}
+
+func TestListExplorerWorkspaces_RateLimitExhausted(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	cw := newTestClientWrapper(ts.URL)
+	_, err := cw.ListExplorerWorkspaces(context.Background(), "my-org", ExplorerListOptions{})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got: %v", err)
+	}
+	if got := calls.Load(); got != defaultMaxRetries+1 {
+		t.Errorf("expected %d calls, got %d", defaultMaxRetries+1, got)
+	}
+}
+
+func TestReadLogChunk(t *testing.T) {
+	var gotQuery url.Values
+	var gotAuth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte("log output\n"))
+	}))
+	defer ts.Close()
+
+	cw := newTestClientWrapper("https://app.terraform.io")
+	chunk, err := cw.ReadLogChunk(context.Background(), ts.URL+"/v1/object/signed?token=abc", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(chunk) != "log output\n" {
+		t.Errorf("unexpected chunk: %q", chunk)
+	}
+	if gotQuery.Get("offset") != "42" || gotQuery.Get("limit") == "" {
+		t.Errorf("unexpected query: %v", gotQuery)
+	}
+	if gotQuery.Get("token") != "abc" {
+		t.Errorf("expected signed URL query to be preserved, got %v", gotQuery)
+	}
+	if gotAuth != "" {
+		t.Errorf("expected no Authorization header, got %q", gotAuth)
+	}
+}
+
+func TestReadLogChunk_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	cw := newTestClientWrapper(ts.URL)
+	_, err := cw.ReadLogChunk(context.Background(), ts.URL+"/logs", 0)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// gitRemoteURL returns the URL of the current directory's Git remote.
+// It prioritizes 'origin' remote if multiple remotes exist.
+func gitRemoteURL(ctx context.Context) (string, error) {
+	// Try to get origin remote URL first
+	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", "origin")
+	output, err := cmd.Output()
+	if err != nil {
+		// If origin doesn't exist, try to get any remote
+		cmd = exec.CommandContext(ctx, "git", "remote")
+		remotesOutput, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("git repository not found in current directory\nPlease specify repository using --repo flag (e.g., --repo owner/repo)")
+		}
+
+		remotes := strings.Fields(string(remotesOutput))
+		if len(remotes) == 0 {
+			return "", fmt.Errorf("no git remote found in current directory\nPlease specify repository using --repo flag (e.g., --repo owner/repo)")
+		}
+
+		// Get URL of the first remote
+		cmd = exec.CommandContext(ctx, "git", "remote", "get-url", remotes[0]) //nolint:gosec // G204: remotes come from git config, not user input
+		output, err = cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to get git remote URL\nPlease specify repository using --repo flag (e.g., --repo owner/repo)")
+		}
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// vcsHostname returns the hostname of a GitHub or GitLab host given as a
+// hostname or a URL.
+func vcsHostname(host string) string {
+	if !strings.Contains(host, "://") {
+		return strings.TrimSuffix(host, "/")
+	}
+	u, err := url.Parse(host)
+	if err != nil || u.Host == "" {
+		return host
+	}
+	return u.Hostname()
+}
+
+// scpLikeRemotePattern matches remotes in the scp-like SSH syntax
+// ([user@]host:path), capturing the host and the path.
+var scpLikeRemotePattern = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):([^/].*)$`)
+
+// parseRemoteURL splits a Git remote URL into its hostname and repository
+// path without the ".git" suffix. Supports the scp-like SSH (git@host:path),
+// ssh://, and HTTP(S) formats, with any user and port.
+func parseRemoteURL(remoteURL string) (host, path string, ok bool) {
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return "", "", false
+		}
+		switch u.Scheme {
+		case "ssh", "git+ssh", "https", "http":
+			host, path = u.Hostname(), u.Path
+		default:
+			return "", "", false
+		}
+	} else if matches := scpLikeRemotePattern.FindStringSubmatch(remoteURL); matches != nil {
+		host, path = matches[1], matches[2]
+	} else {
+		return "", "", false
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" || slices.Contains(strings.Split(path, "/"), "") {
+		return "", "", false
+	}
+	return host, path, true
+}
+
+// StatusRun is an HCP Terraform run linked from a commit status or check run.
+type StatusRun struct {
+	// Context is the name of the commit status or check run.
+	Context string
+	// Workspace is the workspace name in the run URL, or empty if the URL
+	// does not include it.
+	Workspace string
+	RunID     string
+	URL       string
+}
+
+// statusRuns collects the HCP Terraform runs linked from the commit statuses
+// of a pull request or merge request, keeping the latest run per context and
+// each run once.
+type statusRuns struct {
+	pattern   *regexp.Regexp
+	byContext map[string]StatusRun
+	seen      map[string]bool
+}
+
+func newStatusRuns(address string) *statusRuns {
+	return &statusRuns{pattern: runURLPattern(address), byContext: make(map[string]StatusRun), seen: make(map[string]bool)}
+}
+
+// workspaceURLPattern captures the workspace name of a run URL such as
+// https://app.terraform.io/app/my-org/workspaces/my-workspace/runs/run-abc123.
+var workspaceURLPattern = regexp.MustCompile(`/workspaces/([^/]+)/runs/`)
+
+// add records the run linked from targetURL. Statuses must be added newest
+// first, as only the first run of each context is kept. A run reported under
+// several contexts, e.g. as both a commit status and a check run, is kept
+// under the first one.
+func (s *statusRuns) add(context, targetURL string) {
+	matches := s.pattern.FindStringSubmatch(targetURL)
+	if matches == nil {
+		return
+	}
+	if _, exists := s.byContext[context]; exists {
+		return
+	}
+	if s.seen[matches[2]] {
+		return
+	}
+	s.seen[matches[2]] = true
+	sr := StatusRun{Context: context, RunID: matches[2], URL: targetURL}
+	if ws := workspaceURLPattern.FindStringSubmatch(targetURL); ws != nil {
+		sr.Workspace = ws[1]
+	}
+	s.byContext[context] = sr
+}
+
+// list returns the collected runs sorted by context.
+func (s *statusRuns) list() []StatusRun {
+	runs := make([]StatusRun, 0, len(s.byContext))
+	for _, sr := range s.byContext {
+		runs = append(runs, sr)
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Context < runs[j].Context
+	})
+	return runs
+}
+
+// pick returns the run for workspaceName, or the only run when workspaceName
+// is empty. ref names the pull request or merge request in errors, e.g. "PR #42".
+func (s *statusRuns) pick(ref, workspaceName string) (string, error) {
+	runs := s.list()
+	if len(runs) == 0 {
+		return "", fmt.Errorf("no HCP Terraform run found in %s", ref)
+	}
+
+	// If workspace name is specified, filter by context
+	if workspaceName != "" {
+		for _, sr := range runs {
+			if strings.Contains(sr.Context, workspaceName) {
+				return sr.RunID, nil
+			}
+		}
+		return "", fmt.Errorf("no HCP Terraform run found for workspace '%s' in %s", workspaceName, ref)
+	}
+
+	// If only one run found, return it
+	if len(runs) == 1 {
+		return runs[0].RunID, nil
+	}
+
+	// Multiple runs found, list contexts for user guidance
+	lines := make([]string, 0, len(runs))
+	for _, sr := range runs {
+		lines = append(lines, fmt.Sprintf("  - %s", sr.Context))
+	}
+	return "", fmt.Errorf("multiple HCP Terraform runs found in %s:\n%s\nUse --workspace/-w to specify which one", ref, strings.Join(lines, "\n"))
+}
+
+// runURLPattern returns the pattern of run URLs on the host of the given
+// HCP Terraform or Terraform Enterprise address, capturing the run ID.
+func runURLPattern(address string) *regexp.Regexp {
+	host := regexp.QuoteMeta(hostnameFromAddress(address))
+	return regexp.MustCompile(`//` + host + `(:[0-9]+)?/.+/runs/(run-[A-Za-z0-9]+)`)
+}
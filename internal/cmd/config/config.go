@@ -6,9 +6,12 @@ import (
 
 // ValidKeys defines the set of valid configuration keys.
 var ValidKeys = map[string]bool{
-	"org":     true,
-	"token":   true,
-	"address": true,
+	"org":         true,
+	"token":       true,
+	"address":     true,
+	"timeout":     true,
+	"github-host": true,
+	"gitlab-host": true,
 }
 
 // NewCmdConfig returns the config parent command.
@@ -21,6 +24,7 @@ func NewCmdConfig() *cobra.Command {
 	cmd.AddCommand(newCmdConfigSet())
 	cmd.AddCommand(newCmdConfigGet())
 	cmd.AddCommand(newCmdConfigList())
+	cmd.AddCommand(newCmdConfigUseProfile())
 
 	return cmd
 }
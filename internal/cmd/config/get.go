@@ -14,9 +14,12 @@ func newCmdConfigGet() *cobra.Command {
 		Long: `Get a configuration value.
 
 Available keys:
-  org       HCP Terraform organization name
-  token     API token (masked for security)
-  address   HCP Terraform API address`,
+  org           HCP Terraform organization name
+  token         API token (masked for security)
+  address       HCP Terraform API address
+  timeout       Per-request API timeout (e.g. 30s, 1m)
+  github-host   GitHub host for --pr (e.g. github.example.com)
+  gitlab-host   GitLab host for --mr (e.g. gitlab.example.com)`,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -28,7 +31,7 @@ Available keys:
 
 func runConfigGet(key string) error {
 	if !ValidKeys[key] {
-		return fmt.Errorf("unknown config key %q (valid keys: org, token, address)", key)
+		return fmt.Errorf("unknown config key %q (valid keys: org, token, address, timeout, github-host, gitlab-host)", key)
 	}
 
 	value := viper.GetString(key)
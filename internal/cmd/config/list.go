@@ -35,8 +35,13 @@ func runConfigList() error {
 	}
 	sort.Strings(keys)
 
+	profile := ActiveProfile()
+
 	if viper.GetBool("json") {
-		items := make([]configJSON, 0, len(keys))
+		items := make([]configJSON, 0, len(keys)+1)
+		if profile != "" {
+			items = append(items, configJSON{Key: "profile", Value: profile})
+		}
 		for _, key := range keys {
 			value := viper.GetString(key)
 			if key == "token" && value != "" {
@@ -50,7 +55,10 @@ func runConfigList() error {
 		return output.PrintJSON(os.Stdout, items)
 	}
 
-	pairs := make([]output.KeyValue, 0, len(keys))
+	pairs := make([]output.KeyValue, 0, len(keys)+1)
+	if profile != "" {
+		pairs = append(pairs, output.KeyValue{Key: "profile", Value: profile})
+	}
 	for _, key := range keys {
 		value := viper.GetString(key)
 		if key == "token" && value != "" {
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// profilesKey is the config file key holding named profiles.
+	profilesKey = "profiles"
+
+	// currentProfileKey is the config file key holding the profile selected
+	// with 'hcpt config use-profile'.
+	currentProfileKey = "current-profile"
+
+	defaultAddress = "https://app.terraform.io"
+)
+
+// connectionKeys are the keys a profile fully owns: when a profile is active,
+// top-level values for these keys are ignored so that a token meant for one
+// host is never sent to another.
+var connectionKeys = []string{"token", "address", "org"}
+
+// ActiveProfile returns the name of the selected profile, normalized with
+// normalizeProfileName.
+// Priority: --profile flag > HCPT_PROFILE env > current-profile in config file.
+func ActiveProfile() string {
+	if name := viper.GetString("profile"); name != "" {
+		return normalizeProfileName(name)
+	}
+	return normalizeProfileName(viper.GetString(currentProfileKey))
+}
+
+// normalizeProfileName lower-cases a profile name. Viper lower-cases nested
+// keys when reading the config file, so profiles are always stored and
+// looked up by their lower-case name.
+func normalizeProfileName(name string) string {
+	return strings.ToLower(name)
+}
+
+// profileEntry returns the values of the named profile in the raw config
+// file profiles, moving values stored under a differently-cased name (written
+// by older versions) to the normalized name.
+func profileEntry(profiles map[string]interface{}, name string) (map[string]interface{}, bool) {
+	for key, raw := range profiles {
+		if key != name && normalizeProfileName(key) == name {
+			if _, ok := profiles[name]; !ok {
+				profiles[name] = raw
+			}
+			delete(profiles, key)
+		}
+	}
+	raw, ok := profiles[name]
+	values, _ := raw.(map[string]interface{})
+	return values, ok
+}
+
+// ApplyProfile layers the active profile's values over the top-level config
+// file values. Flags and environment variables still take precedence.
+// It does nothing when no profile is selected. If the profile does not exist,
+// the top-level connection values are still cleared and an error is returned,
+// so that callers can warn while 'hcpt config set' creates the profile.
+func ApplyProfile() error {
+	name := ActiveProfile()
+	if name == "" {
+		return nil
+	}
+
+	values, found := profileValues(name)
+
+	merged := make(map[string]interface{}, len(values)+len(connectionKeys))
+	for _, key := range connectionKeys {
+		merged[key] = ""
+	}
+	merged["address"] = defaultAddress
+	for key, value := range values {
+		if ValidKeys[key] {
+			merged[key] = value
+		}
+	}
+
+	if err := viper.MergeConfigMap(merged); err != nil {
+		return fmt.Errorf("failed to apply profile %q: %w", name, err)
+	}
+	viper.Set("profile", name)
+
+	if !found {
+		return fmt.Errorf("profile %q not found in config file", name)
+	}
+	return nil
+}
+
+// profileValues returns the values defined for the named profile.
+func profileValues(name string) (map[string]interface{}, bool) {
+	raw, ok := viper.GetStringMap(profilesKey)[normalizeProfileName(name)]
+	if !ok {
+		return nil, false
+	}
+	values, _ := raw.(map[string]interface{})
+	return values, true
+}
+
+// configFilePath returns the config file in use, or ~/.hcpt.yaml.
+func configFilePath() (string, error) {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".hcpt.yaml"), nil
+}
+
+// readConfigFile reads the raw config file. A missing or invalid file yields an empty map.
+func readConfigFile(path string) map[string]interface{} {
+	existing := make(map[string]interface{})
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed from user home dir, not user-controlled input
+	if err == nil {
+		_ = yaml.Unmarshal(data, &existing)
+	}
+	return existing
+}
+
+// writeConfigFile writes the raw config file with owner-only permissions.
+func writeConfigFile(path string, values map[string]interface{}) error {
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(path, out, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+const profileTestConfig = `
+token: top-level-token
+address: https://app.terraform.io
+org: top-org
+timeout: 45s
+current-profile: staging
+profiles:
+  prod-tfe:
+    token: prod-token
+    address: https://tfe.example.com
+    org: prod-org
+  staging:
+    address: https://staging.example.com
+    org: staging-org
+`
+
+func loadProfileTestConfig(t *testing.T) {
+	t.Helper()
+	viper.Reset()
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(strings.NewReader(profileTestConfig)); err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+}
+
+func TestApplyProfile_NoProfile(t *testing.T) {
+	viper.Reset()
+	viper.Set("org", "my-org")
+
+	if err := ApplyProfile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := viper.GetString("org"); got != "my-org" {
+		t.Errorf("expected org %q, got %q", "my-org", got)
+	}
+}
+
+func TestApplyProfile_ExplicitProfile(t *testing.T) {
+	loadProfileTestConfig(t)
+	viper.Set("profile", "prod-tfe")
+
+	if err := ApplyProfile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for key, want := range map[string]string{
+		"token":   "prod-token",
+		"address": "https://tfe.example.com",
+		"org":     "prod-org",
+		"timeout": "45s",
+	} {
+		if got := viper.GetString(key); got != want {
+			t.Errorf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestApplyProfile_CurrentProfile(t *testing.T) {
+	loadProfileTestConfig(t)
+
+	if err := ApplyProfile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ActiveProfile(); got != "staging" {
+		t.Errorf("expected active profile %q, got %q", "staging", got)
+	}
+	if got := viper.GetString("org"); got != "staging-org" {
+		t.Errorf("expected org %q, got %q", "staging-org", got)
+	}
+	// The top-level token belongs to another host and must not leak into the profile.
+	if got := viper.GetString("token"); got != "" {
+		t.Errorf("expected empty token, got %q", got)
+	}
+}
+
+func TestApplyProfile_CaseInsensitiveName(t *testing.T) {
+	loadProfileTestConfig(t)
+	viper.Set("profile", "Prod-TFE")
+
+	if err := ApplyProfile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := viper.GetString("org"); got != "prod-org" {
+		t.Errorf("expected org %q, got %q", "prod-org", got)
+	}
+}
+
+func TestApplyProfile_NotFound(t *testing.T) {
+	loadProfileTestConfig(t)
+	viper.Set("profile", "missing")
+
+	err := ApplyProfile()
+	if err == nil {
+		t.Fatal("expected error for unknown profile")
+	}
+	if !strings.Contains(err.Error(), `profile "missing" not found`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := viper.GetString("token"); got != "" {
+		t.Errorf("expected top-level token to be cleared, got %q", got)
+	}
+}
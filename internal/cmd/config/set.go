@@ -3,11 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"go.yaml.in/yaml/v3"
 )
 
 func newCmdConfigSet() *cobra.Command {
@@ -17,9 +15,15 @@ func newCmdConfigSet() *cobra.Command {
 		Long: `Set a configuration value in the hcpt config file.
 
 Available keys:
-  org       HCP Terraform organization name
-  token     API token
-  address   HCP Terraform API address`,
+  org           HCP Terraform organization name
+  token         API token
+  address       HCP Terraform API address
+  timeout       Per-request API timeout (e.g. 30s, 1m)
+  github-host   GitHub host for --pr (e.g. github.example.com)
+  gitlab-host   GitLab host for --mr (e.g. gitlab.example.com)
+
+When a profile is active (--profile, HCPT_PROFILE, or 'hcpt config use-profile'),
+the value is stored in that profile.`,
 		Args:         cobra.ExactArgs(2),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -31,35 +35,46 @@ Available keys:
 
 func runConfigSet(key, value string) error {
 	if !ValidKeys[key] {
-		return fmt.Errorf("unknown config key %q (valid keys: org, token, address)", key)
+		return fmt.Errorf("unknown config key %q (valid keys: org, token, address, timeout, github-host, gitlab-host)", key)
 	}
-
-	configPath := viper.ConfigFileUsed()
-	if configPath == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
+	if key == "timeout" {
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("invalid timeout %q: must be a positive duration (e.g. 30s, 1m)", value)
 		}
-		configPath = filepath.Join(home, ".hcpt.yaml")
 	}
 
-	existing := make(map[string]string)
-	data, err := os.ReadFile(configPath) //nolint:gosec // G304: path is constructed from user home dir, not user-controlled input
-	if err == nil {
-		_ = yaml.Unmarshal(data, &existing)
+	configPath, err := configFilePath()
+	if err != nil {
+		return err
 	}
 
-	existing[key] = value
+	existing := readConfigFile(configPath)
 
-	out, err := yaml.Marshal(existing)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	profile := ActiveProfile()
+	if profile == "" {
+		existing[key] = value
+	} else {
+		profiles, _ := existing[profilesKey].(map[string]interface{})
+		if profiles == nil {
+			profiles = make(map[string]interface{})
+		}
+		values, _ := profileEntry(profiles, profile)
+		if values == nil {
+			values = make(map[string]interface{})
+		}
+		values[key] = value
+		profiles[profile] = values
+		existing[profilesKey] = profiles
 	}
 
-	if err := os.WriteFile(configPath, out, 0o600); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+	if err := writeConfigFile(configPath, existing); err != nil {
+		return err
 	}
 
+	if profile != "" {
+		fmt.Fprintf(os.Stderr, "Set %q to %q for profile %q in %s\n", key, value, profile, configPath)
+		return nil
+	}
 	fmt.Fprintf(os.Stderr, "Set %q to %q in %s\n", key, value, configPath)
 	return nil
 }
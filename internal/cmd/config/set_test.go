@@ -77,6 +77,21 @@ func TestRunConfigSet(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid timeout", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), ".hcpt.yaml")
+		viper.Reset()
+		viper.SetConfigFile(configPath)
+
+		for _, value := range []string{"abc", "30", "0s", "-1m"} {
+			if err := runConfigSet("timeout", value); err == nil {
+				t.Errorf("expected error for timeout %q", value)
+			}
+		}
+		if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+			t.Errorf("expected config file not to be written, got %v", err)
+		}
+	})
+
 	t.Run("default config path when no config file used", func(t *testing.T) {
 		home := t.TempDir()
 		t.Setenv("HOME", home)
@@ -101,4 +116,74 @@ func TestRunConfigSet(t *testing.T) {
 			t.Errorf("expected org=%q, got %q", "default-path-org", got["org"])
 		}
 	})
+
+	t.Run("set value in active profile", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), ".hcpt.yaml")
+		if err := os.WriteFile(configPath, []byte("org: top-org\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		viper.Reset()
+		viper.SetConfigFile(configPath)
+		viper.Set("profile", "prod-tfe")
+
+		if err := runConfigSet("address", "https://tfe.example.com"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := os.ReadFile(configPath) //nolint:gosec // G304: test reads a temp file path
+		if err != nil {
+			t.Fatalf("failed to read config: %v", err)
+		}
+
+		var got struct {
+			Org      string                       `yaml:"org"`
+			Address  string                       `yaml:"address"`
+			Profiles map[string]map[string]string `yaml:"profiles"`
+		}
+		if err := yaml.Unmarshal(data, &got); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+
+		if got.Org != "top-org" {
+			t.Errorf("expected top-level org to be preserved, got %q", got.Org)
+		}
+		if got.Address != "" {
+			t.Errorf("expected top-level address to be untouched, got %q", got.Address)
+		}
+		if got.Profiles["prod-tfe"]["address"] != "https://tfe.example.com" {
+			t.Errorf("expected profile address to be set, got %v", got.Profiles)
+		}
+	})
+	t.Run("profile names are normalized", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), ".hcpt.yaml")
+		initial := "profiles:\n  Prod:\n    org: prod-org\n"
+		if err := os.WriteFile(configPath, []byte(initial), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		viper.Reset()
+		viper.SetConfigFile(configPath)
+		viper.Set("profile", "PROD")
+
+		if err := runConfigSet("address", "https://tfe.example.com"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := os.ReadFile(configPath) //nolint:gosec // G304: test reads a temp file path
+		if err != nil {
+			t.Fatalf("failed to read config: %v", err)
+		}
+
+		var got struct {
+			Profiles map[string]map[string]string `yaml:"profiles"`
+		}
+		if err := yaml.Unmarshal(data, &got); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+
+		want := map[string]string{"org": "prod-org", "address": "https://tfe.example.com"}
+		if len(got.Profiles) != 1 || len(got.Profiles["prod"]) != 2 ||
+			got.Profiles["prod"]["org"] != want["org"] || got.Profiles["prod"]["address"] != want["address"] {
+			t.Errorf("expected profile %q with %v, got %v", "prod", want, got.Profiles)
+		}
+	})
 }
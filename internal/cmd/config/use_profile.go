@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newCmdConfigUseProfile() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use-profile <name>",
+		Short: "Set the default connection profile",
+		Long: `Set the default connection profile in the hcpt config file.
+
+Profiles are defined under 'profiles' in the config file and can be created
+with 'hcpt config set <key> <value> --profile <name>'. The --profile flag and
+HCPT_PROFILE environment variable take precedence over the default profile.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigUseProfile(args[0])
+		},
+	}
+	return cmd
+}
+
+func runConfigUseProfile(name string) error {
+	name = normalizeProfileName(name)
+
+	configPath, err := configFilePath()
+	if err != nil {
+		return err
+	}
+
+	existing := readConfigFile(configPath)
+
+	profiles, _ := existing[profilesKey].(map[string]interface{})
+	if _, ok := profileEntry(profiles, name); !ok {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("profile %q not found: no profiles defined in %s", name, configPath)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("profile %q not found (available profiles: %s)", name, strings.Join(names, ", "))
+	}
+
+	existing[currentProfileKey] = name
+	if err := writeConfigFile(configPath, existing); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Switched to profile %q in %s\n", name, configPath)
+	return nil
+}
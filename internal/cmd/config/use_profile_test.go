@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestRunConfigUseProfile(t *testing.T) {
+	t.Run("switch to existing profile", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), ".hcpt.yaml")
+		initial := "org: top-org\nprofiles:\n  prod-tfe:\n    org: prod-org\n"
+		if err := os.WriteFile(configPath, []byte(initial), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		viper.Reset()
+		viper.SetConfigFile(configPath)
+
+		if err := runConfigUseProfile("prod-tfe"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := os.ReadFile(configPath) //nolint:gosec // G304: test reads a temp file path
+		if err != nil {
+			t.Fatalf("failed to read config: %v", err)
+		}
+		var got map[string]interface{}
+		if err := yaml.Unmarshal(data, &got); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+		if got["current-profile"] != "prod-tfe" {
+			t.Errorf("expected current-profile=%q, got %v", "prod-tfe", got["current-profile"])
+		}
+		if got["org"] != "top-org" {
+			t.Errorf("expected org to be preserved, got %v", got["org"])
+		}
+	})
+
+	t.Run("profile name is case-insensitive", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), ".hcpt.yaml")
+		initial := "profiles:\n  Prod:\n    org: prod-org\n"
+		if err := os.WriteFile(configPath, []byte(initial), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		viper.Reset()
+		viper.SetConfigFile(configPath)
+
+		if err := runConfigUseProfile("PROD"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := os.ReadFile(configPath) //nolint:gosec // G304: test reads a temp file path
+		if err != nil {
+			t.Fatalf("failed to read config: %v", err)
+		}
+		var got struct {
+			CurrentProfile string                       `yaml:"current-profile"`
+			Profiles       map[string]map[string]string `yaml:"profiles"`
+		}
+		if err := yaml.Unmarshal(data, &got); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+		if got.CurrentProfile != "prod" {
+			t.Errorf("expected current-profile=%q, got %q", "prod", got.CurrentProfile)
+		}
+		if got.Profiles["prod"]["org"] != "prod-org" {
+			t.Errorf("expected profile to be stored as %q, got %v", "prod", got.Profiles)
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), ".hcpt.yaml")
+		initial := "profiles:\n  prod-tfe:\n    org: prod-org\n  dev:\n    org: dev-org\n"
+		if err := os.WriteFile(configPath, []byte(initial), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		viper.Reset()
+		viper.SetConfigFile(configPath)
+
+		err := runConfigUseProfile("missing")
+		if err == nil {
+			t.Fatal("expected error for unknown profile")
+		}
+		if !strings.Contains(err.Error(), "available profiles: dev, prod-tfe") {
+			t.Errorf("expected available profiles in error, got: %v", err)
+		}
+	})
+
+	t.Run("no profiles defined", func(t *testing.T) {
+		viper.Reset()
+		viper.SetConfigFile(filepath.Join(t.TempDir(), ".hcpt.yaml"))
+
+		err := runConfigUseProfile("prod-tfe")
+		if err == nil {
+			t.Fatal("expected error when no profiles are defined")
+		}
+		if !strings.Contains(err.Error(), "no profiles defined") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
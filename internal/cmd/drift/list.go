@@ -112,38 +112,6 @@ func filterByProject(items []client.ExplorerWorkspace, include, exclude []string
 	return filtered
 }
 
-// tagFilter is a parsed --tag/--exclude-tag argument: either a bare key
-// (matches a key-only tag, or any key-value tag with this key) or a
-// "key:value" pair (matches only that exact key-value tag).
-type tagFilter struct {
-	key      string
-	value    string
-	hasValue bool
-}
-
-func parseTagFilter(s string) tagFilter {
-	if key, value, found := strings.Cut(s, ":"); found {
-		return tagFilter{key: key, value: value, hasValue: true}
-	}
-	return tagFilter{key: s}
-}
-
-// matches reports whether any of the workspace's tags (each "key" or
-// "key:value") satisfies this filter.
-func (f tagFilter) matches(tags []string) bool {
-	for _, t := range tags {
-		key, value, hasValue := strings.Cut(t, ":")
-		if f.hasValue {
-			if hasValue && key == f.key && value == f.value {
-				return true
-			}
-		} else if key == f.key {
-			return true
-		}
-	}
-	return false
-}
-
 // filterByTag applies client-side include/exclude filtering by workspace tag.
 // As with filterByProject, this happens client-side because the Explorer
 // API's filter query parameters only honor a single value per field+operator.
@@ -151,21 +119,13 @@ func filterByTag(items []client.ExplorerWorkspace, include, exclude []string) []
 	if len(include) == 0 && len(exclude) == 0 {
 		return items
 	}
-	includeFilters := make([]tagFilter, len(include))
-	for i, s := range include {
-		includeFilters[i] = parseTagFilter(s)
-	}
-	excludeFilters := make([]tagFilter, len(exclude))
-	for i, s := range exclude {
-		excludeFilters[i] = parseTagFilter(s)
-	}
 
 	filtered := make([]client.ExplorerWorkspace, 0, len(items))
 	for _, item := range items {
-		if len(includeFilters) > 0 && !anyTagMatches(includeFilters, item.Tags) {
+		if len(include) > 0 && !slices.ContainsFunc(include, item.HasTag) {
 			continue
 		}
-		if anyTagMatches(excludeFilters, item.Tags) {
+		if slices.ContainsFunc(exclude, item.HasTag) {
 			continue
 		}
 		filtered = append(filtered, item)
@@ -173,15 +133,6 @@ func filterByTag(items []client.ExplorerWorkspace, include, exclude []string) []
 	return filtered
 }
 
-func anyTagMatches(filters []tagFilter, tags []string) bool {
-	for _, f := range filters {
-		if f.matches(tags) {
-			return true
-		}
-	}
-	return false
-}
-
 type driftJSON struct {
 	Workspace          string `json:"workspace"`
 	Drifted            bool   `json:"drifted"`
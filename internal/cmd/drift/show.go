@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -13,6 +12,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/diff"
 	"github.com/nnstt1/hcpt/internal/output"
 )
 
@@ -144,7 +144,7 @@ func toDriftShowJSON(ws *tfe.Workspace, result *client.AssessmentResult, resourc
 			Action:  r.Action,
 		}
 		if verbose {
-			diffs := computeDiffs(r.Before, r.After, r.AfterUnknown, r.BeforeSensitive, r.AfterSensitive)
+			diffs := diff.Compute(r.Before, r.After, r.AfterUnknown, r.BeforeSensitive, r.AfterSensitive)
 			if len(diffs) > 0 {
 				rj.Changes = make(map[string]driftChange, len(diffs))
 				for _, d := range diffs {
@@ -186,190 +186,10 @@ func buildDriftShowKeyValues(ws *tfe.Workspace, result *client.AssessmentResult)
 	return pairs
 }
 
-// attributeDiff represents a single attribute-level change between before and after states.
-type attributeDiff struct {
-	Key             string
-	Before          string
-	After           string
-	BeforeRaw       interface{}
-	AfterRaw        interface{}
-	KnownAfterApply bool
-	Sensitive       bool
-}
-
-// flattenMap recursively flattens a nested map into dot-notation keys.
-// Empty maps and empty arrays are stored as leaf values to distinguish them from nil.
-func flattenMap(prefix string, value interface{}, result map[string]interface{}) {
-	switch v := value.(type) {
-	case map[string]interface{}:
-		if len(v) == 0 && prefix != "" {
-			result[prefix] = value
-			return
-		}
-		for k, val := range v {
-			key := k
-			if prefix != "" {
-				key = prefix + "." + k
-			}
-			flattenMap(key, val, result)
-		}
-	case []interface{}:
-		if len(v) == 0 && prefix != "" {
-			result[prefix] = value
-			return
-		}
-		for i, val := range v {
-			key := fmt.Sprintf("%s.%d", prefix, i)
-			flattenMap(key, val, result)
-		}
-	default:
-		result[prefix] = value
-	}
-}
-
-// isKnownAfterApply checks if a flattened key (e.g. "a.b.c") is marked as unknown
-// in flatAfterUnknown. It checks the key itself and all ancestor keys, because
-// after_unknown may mark an entire parent object as true (e.g. "a": true) rather
-// than listing each child individually.
-func isKnownAfterApply(key string, flatAfterUnknown map[string]interface{}) bool {
-	if flatAfterUnknown[key] == true {
-		return true
-	}
-	for i := len(key) - 1; i >= 0; i-- {
-		if key[i] == '.' {
-			if flatAfterUnknown[key[:i]] == true {
-				return true
-			}
-		}
-	}
-	return false
-}
-
-// computeDiffs compares before and after maps and returns sorted attribute diffs.
-// afterUnknown marks attributes whose after value will be known only after apply.
-// beforeSensitive/afterSensitive mark attributes whose values must not be displayed.
-func computeDiffs(before, after, afterUnknown map[string]interface{}, beforeSensitive, afterSensitive interface{}) []attributeDiff { //nolint:gocyclo // complex by nature, refactor tracked in separate issue
-	flatBefore := make(map[string]interface{})
-	flatAfter := make(map[string]interface{})
-	flatAfterUnknown := make(map[string]interface{})
-	flatBeforeSensitive := make(map[string]interface{})
-	flatAfterSensitive := make(map[string]interface{})
-
-	if before != nil {
-		flattenMap("", before, flatBefore)
-	}
-	if after != nil {
-		flattenMap("", after, flatAfter)
-	}
-	if afterUnknown != nil {
-		flattenMap("", afterUnknown, flatAfterUnknown)
-	}
-	if m, ok := beforeSensitive.(map[string]interface{}); ok {
-		flattenMap("", m, flatBeforeSensitive)
-	}
-	if m, ok := afterSensitive.(map[string]interface{}); ok {
-		flattenMap("", m, flatAfterSensitive)
-	}
-
-	// Collect all keys from before and after
-	keys := make(map[string]struct{})
-	for k := range flatBefore {
-		keys[k] = struct{}{}
-	}
-	for k := range flatAfter {
-		keys[k] = struct{}{}
-	}
-
-	var diffs []attributeDiff
-	for k := range keys {
-		bVal, bOk := flatBefore[k]
-		aVal, aOk := flatAfter[k]
-		unknown := isKnownAfterApply(k, flatAfterUnknown)
-		sensitive := isKnownAfterApply(k, flatBeforeSensitive) || isKnownAfterApply(k, flatAfterSensitive)
-
-		// Raw strings for change detection; display strings for output
-		rawBStr := formatDiffValue(bVal)
-		rawAStr := formatDiffValue(aVal)
-		dispBStr := rawBStr
-		if sensitive {
-			dispBStr = "(sensitive value)"
-		}
-
-		switch {
-		case !bOk:
-			// Added
-			if aVal == nil && !unknown {
-				continue
-			}
-			dispAStr := "(known after apply)"
-			if !unknown {
-				dispAStr = rawAStr
-				if sensitive {
-					dispAStr = "(sensitive value)"
-				}
-			}
-			diffs = append(diffs, attributeDiff{Key: k, Before: "(null)", After: dispAStr, BeforeRaw: nil, AfterRaw: aVal, KnownAfterApply: unknown, Sensitive: sensitive})
-		case !aOk || unknown:
-			// Removed or known-after-apply (key absent from after, or parent marked unknown)
-			if bVal == nil && !unknown {
-				continue
-			}
-			if unknown {
-				diffs = append(diffs, attributeDiff{Key: k, Before: dispBStr, After: "(known after apply)", BeforeRaw: bVal, AfterRaw: nil, KnownAfterApply: true, Sensitive: sensitive})
-			} else {
-				diffs = append(diffs, attributeDiff{Key: k, Before: dispBStr, After: "(null)", BeforeRaw: bVal, AfterRaw: nil, Sensitive: sensitive})
-			}
-		case rawBStr != rawAStr:
-			// Changed — compare raw values; display masked if sensitive
-			dispAStr := rawAStr
-			if sensitive {
-				dispAStr = "(sensitive value)"
-			}
-			diffs = append(diffs, attributeDiff{Key: k, Before: dispBStr, After: dispAStr, BeforeRaw: bVal, AfterRaw: aVal, Sensitive: sensitive})
-		}
-	}
-
-	sort.Slice(diffs, func(i, j int) bool {
-		return diffs[i].Key < diffs[j].Key
-	})
-
-	return diffs
-}
-
-// formatDiffValue converts a value to a display string.
-func formatDiffValue(v interface{}) string {
-	if v == nil {
-		return "(null)"
-	}
-	switch val := v.(type) {
-	case string:
-		return fmt.Sprintf("%q", val)
-	case float64:
-		if val == float64(int64(val)) {
-			return fmt.Sprintf("%d", int64(val))
-		}
-		return fmt.Sprintf("%g", val)
-	case bool:
-		return strconv.FormatBool(val)
-	case []interface{}:
-		if len(val) == 0 {
-			return "[]"
-		}
-		return fmt.Sprintf("%v", val)
-	case map[string]interface{}:
-		if len(val) == 0 {
-			return "{}"
-		}
-		return fmt.Sprintf("%v", val)
-	default:
-		return fmt.Sprintf("%v", val)
-	}
-}
-
 // printResourceDiffs prints attribute-level diffs for each drifted resource.
 func printResourceDiffs(w *os.File, resources []client.DriftedResource) {
 	for _, r := range resources {
-		diffs := computeDiffs(r.Before, r.After, r.AfterUnknown, r.BeforeSensitive, r.AfterSensitive)
+		diffs := diff.Compute(r.Before, r.After, r.AfterUnknown, r.BeforeSensitive, r.AfterSensitive)
 		if len(diffs) == 0 {
 			continue
 		}
@@ -386,9 +206,9 @@ func printResourceDiffs(w *os.File, resources []client.DriftedResource) {
 		for _, d := range diffs {
 			var symbol string
 			switch {
-			case d.Before == "(null)":
+			case d.Before == diff.NullValue:
 				symbol = "+"
-			case d.After == "(null)":
+			case d.After == diff.NullValue:
 				symbol = "-"
 			default:
 				symbol = "~"
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -42,15 +43,30 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// ExitCode returns the process exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	var exitErr *run.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.hcpt.yaml)")
+	rootCmd.PersistentFlags().String("profile", "", "connection profile name from the config file")
 	rootCmd.PersistentFlags().String("org", "", "HCP Terraform organization name")
 	rootCmd.PersistentFlags().Bool("json", false, "output in JSON format")
+	rootCmd.PersistentFlags().String("github-host", "", "GitHub host for --pr, e.g. a GitHub Enterprise Server hostname (default github.com)")
+	rootCmd.PersistentFlags().String("gitlab-host", "", "GitLab host for --mr, e.g. a self-managed GitLab hostname (default gitlab.com)")
 
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	_ = viper.BindPFlag("org", rootCmd.PersistentFlags().Lookup("org"))
 	_ = viper.BindPFlag("json", rootCmd.PersistentFlags().Lookup("json"))
+	_ = viper.BindPFlag("github-host", rootCmd.PersistentFlags().Lookup("github-host"))
+	_ = viper.BindPFlag("gitlab-host", rootCmd.PersistentFlags().Lookup("gitlab-host"))
 
 	rootCmd.AddCommand(config.NewCmdConfig())
 	rootCmd.AddCommand(drift.NewCmdDrift())
@@ -81,6 +97,10 @@ func initConfig() {
 	// Map environment variables
 	_ = viper.BindEnv("token", "TFE_TOKEN")
 	_ = viper.BindEnv("address", "TFE_ADDRESS")
+	_ = viper.BindEnv("timeout", "HCPT_TIMEOUT")
+	_ = viper.BindEnv("profile", "HCPT_PROFILE")
+	_ = viper.BindEnv("github-host", "GH_HOST")
+	_ = viper.BindEnv("gitlab-host", "GITLAB_HOST")
 
 	// Set defaults
 	viper.SetDefault("address", "https://app.terraform.io")
@@ -94,4 +114,8 @@ func initConfig() {
 			}
 		}
 	}
+
+	if err := config.ApplyProfile(); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	}
 }
@@ -0,0 +1,226 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/prompt"
+)
+
+// runActionService combines the services needed to resolve a run and change its state.
+type runActionService interface {
+	client.RunService
+	client.WorkspaceService
+	client.RunActionService
+}
+
+type runActionClientFactory func() (runActionService, error)
+
+func defaultRunActionClientFactory() (runActionService, error) {
+	return client.NewClientWrapper()
+}
+
+// runAction describes one lifecycle action on a run.
+type runAction struct {
+	Name    string // subcommand name, e.g. "force-cancel"
+	Short   string
+	Verb    string // used in the confirmation prompt, e.g. "Apply"
+	Done    string // used in messages after the action, e.g. "applied"
+	Allowed func(a *tfe.RunActions) bool
+	Do      func(ctx context.Context, svc runActionService, runID, comment string) error
+}
+
+var (
+	runApplyAction = runAction{
+		Name:    "apply",
+		Short:   "Confirm a planned run and start the apply",
+		Verb:    "Apply",
+		Done:    "applied",
+		Allowed: func(a *tfe.RunActions) bool { return a.IsConfirmable },
+		Do: func(ctx context.Context, svc runActionService, runID, comment string) error {
+			return svc.ApplyRun(ctx, runID, comment)
+		},
+	}
+	runDiscardAction = runAction{
+		Name:    "discard",
+		Short:   "Discard a run that is waiting for confirmation",
+		Verb:    "Discard",
+		Done:    "discarded",
+		Allowed: func(a *tfe.RunActions) bool { return a.IsDiscardable },
+		Do: func(ctx context.Context, svc runActionService, runID, comment string) error {
+			return svc.DiscardRun(ctx, runID, comment)
+		},
+	}
+	runCancelAction = runAction{
+		Name:    "cancel",
+		Short:   "Cancel a run that is planning or applying",
+		Verb:    "Cancel",
+		Done:    "canceled",
+		Allowed: func(a *tfe.RunActions) bool { return a.IsCancelable },
+		Do: func(ctx context.Context, svc runActionService, runID, comment string) error {
+			return svc.CancelRun(ctx, runID, comment)
+		},
+	}
+	runForceCancelAction = runAction{
+		Name:    "force-cancel",
+		Short:   "Force-cancel a run and unlock its workspace",
+		Verb:    "Force-cancel",
+		Done:    "force-canceled",
+		Allowed: func(a *tfe.RunActions) bool { return a.IsForceCancelable },
+		Do: func(ctx context.Context, svc runActionService, runID, comment string) error {
+			return svc.ForceCancelRun(ctx, runID, comment)
+		},
+	}
+)
+
+func newCmdRunApply() *cobra.Command {
+	return newCmdRunActionWith(runApplyAction, defaultRunActionClientFactory)
+}
+
+func newCmdRunDiscard() *cobra.Command {
+	return newCmdRunActionWith(runDiscardAction, defaultRunActionClientFactory)
+}
+
+func newCmdRunCancel() *cobra.Command {
+	return newCmdRunActionWith(runCancelAction, defaultRunActionClientFactory)
+}
+
+func newCmdRunForceCancel() *cobra.Command {
+	return newCmdRunActionWith(runForceCancelAction, defaultRunActionClientFactory)
+}
+
+func newCmdRunActionWith(action runAction, clientFn runActionClientFactory) *cobra.Command {
+	var workspaceName string
+	var prNumber int
+	var repoFullName string
+	var comment string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:          action.Name + " [run-id]",
+		Short:        action.Short,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var runID string
+			if len(args) > 0 {
+				runID = args[0]
+			}
+
+			org := viper.GetString("org")
+			if org == "" && workspaceName != "" && prNumber == 0 {
+				return errOrgRequired
+			}
+
+			if runID != "" && prNumber > 0 {
+				return fmt.Errorf("cannot specify both run-id and --pr")
+			}
+
+			if prNumber > 0 && repoFullName == "" {
+				detectedRepo, err := client.DetectGitHubRepository(context.Background())
+				if err != nil {
+					return err
+				}
+				repoFullName = detectedRepo
+			}
+
+			if repoFullName != "" && !strings.Contains(repoFullName, "/") {
+				return fmt.Errorf("--repo must be in format 'owner/repo'")
+			}
+
+			if runID == "" && prNumber == 0 && workspaceName == "" {
+				return fmt.Errorf("either run-id, --pr, or --workspace/-w is required")
+			}
+
+			svc, err := clientFn()
+			if err != nil {
+				return err
+			}
+
+			if prNumber > 0 {
+				runID, err = resolveRunIDFromPR(context.Background(), repoFullName, prNumber, workspaceName)
+				if err != nil {
+					return err
+				}
+			}
+
+			return runRunAction(svc, action, runID, org, workspaceName, comment, yes)
+		},
+	}
+
+	cmd.Flags().StringVarP(&workspaceName, "workspace", "w", "", "workspace name (use latest run)")
+	cmd.Flags().IntVarP(&prNumber, "pr", "p", 0, "GitHub pull request number")
+	cmd.Flags().StringVarP(&repoFullName, "repo", "r", "", "GitHub repository (owner/repo)")
+	cmd.Flags().StringVar(&comment, "comment", "", "comment to record with the action")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
+
+func runRunAction(svc runActionService, action runAction, runID, org, workspaceName, comment string, yes bool) error {
+	ctx := context.Background()
+
+	if runID == "" {
+		var err error
+		runID, err = latestRunID(ctx, svc, org, workspaceName)
+		if err != nil {
+			return err
+		}
+	}
+
+	r, err := svc.ReadRun(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("failed to read run %q: %w", runID, err)
+	}
+
+	if r.Actions != nil && !action.Allowed(r.Actions) {
+		return fmt.Errorf("run %q cannot be %s (status: %s)", runID, action.Done, r.Status)
+	}
+
+	if !yes {
+		if err := displayRun(r, runDetails{}); err != nil {
+			return err
+		}
+		ok, err := prompt.Confirm(fmt.Sprintf("%s run %s?", action.Verb, runID))
+		if err != nil {
+			return fmt.Errorf("failed to read user input: %w", err)
+		}
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Aborted")
+			return nil
+		}
+	}
+
+	if err := action.Do(ctx, svc, runID, comment); err != nil {
+		return fmt.Errorf("failed to %s run %q: %w", action.Name, runID, err)
+	}
+	fmt.Fprintf(os.Stderr, "Run %s %s\n", runID, action.Done)
+	return nil
+}
+
+// latestRunID returns the ID of the most recent run in a workspace.
+func latestRunID(ctx context.Context, svc runActionService, org, workspaceName string) (string, error) {
+	ws, err := svc.ReadWorkspace(ctx, org, workspaceName)
+	if err != nil {
+		return "", fmt.Errorf("failed to read workspace %q: %w", workspaceName, err)
+	}
+
+	runList, err := svc.ListRuns(ctx, ws.ID, &tfe.RunListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 1},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	if len(runList.Items) == 0 {
+		return "", fmt.Errorf("no runs found for workspace %q", workspaceName)
+	}
+	return runList.Items[0].ID, nil
+}
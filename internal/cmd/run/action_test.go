@@ -0,0 +1,273 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/viper"
+)
+
+type mockRunActionService struct {
+	run       *tfe.Run
+	runList   *tfe.RunList
+	actionErr error
+
+	called  string // name of the action method that was called
+	runID   string
+	comment string
+}
+
+func (m *mockRunActionService) ListRuns(_ context.Context, _ string, _ *tfe.RunListOptions) (*tfe.RunList, error) {
+	return m.runList, nil
+}
+
+func (m *mockRunActionService) ReadRun(_ context.Context, runID string) (*tfe.Run, error) {
+	if m.run == nil || m.run.ID != runID {
+		return nil, fmt.Errorf("run %q not found", runID)
+	}
+	return m.run, nil
+}
+
+func (m *mockRunActionService) ReadRunWithApply(ctx context.Context, runID string) (*tfe.Run, error) {
+	return m.ReadRun(ctx, runID)
+}
+
+func (m *mockRunActionService) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, fmt.Errorf("CreateRun not implemented in mockRunActionService")
+}
+
+func (m *mockRunActionService) ListWorkspaces(_ context.Context, _ string, _ *tfe.WorkspaceListOptions) (*tfe.WorkspaceList, error) {
+	return nil, nil
+}
+
+func (m *mockRunActionService) ReadWorkspace(_ context.Context, _, name string) (*tfe.Workspace, error) {
+	return &tfe.Workspace{ID: "ws-abc123", Name: name}, nil
+}
+
+func (m *mockRunActionService) record(name, runID, comment string) error {
+	m.called = name
+	m.runID = runID
+	m.comment = comment
+	return m.actionErr
+}
+
+func (m *mockRunActionService) ApplyRun(_ context.Context, runID, comment string) error {
+	return m.record("apply", runID, comment)
+}
+
+func (m *mockRunActionService) DiscardRun(_ context.Context, runID, comment string) error {
+	return m.record("discard", runID, comment)
+}
+
+func (m *mockRunActionService) CancelRun(_ context.Context, runID, comment string) error {
+	return m.record("cancel", runID, comment)
+}
+
+func (m *mockRunActionService) ForceCancelRun(_ context.Context, runID, comment string) error {
+	return m.record("force-cancel", runID, comment)
+}
+
+func newTestPlannedRun() *tfe.Run {
+	return &tfe.Run{
+		ID:         "run-abc123",
+		Status:     tfe.RunPlanned,
+		HasChanges: true,
+		CreatedAt:  time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC),
+		Plan: &tfe.Plan{
+			ID:                   "plan-abc123",
+			Status:               tfe.PlanFinished,
+			ResourceAdditions:    2,
+			ResourceChanges:      1,
+			ResourceDestructions: 3,
+		},
+		Actions: &tfe.RunActions{
+			IsConfirmable: true,
+			IsDiscardable: true,
+		},
+	}
+}
+
+// withStdin replaces os.Stdin with the given input for the duration of fn.
+func withStdin(t *testing.T, input string, fn func()) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	_, _ = w.WriteString(input)
+	_ = w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() {
+		os.Stdin = oldStdin
+		_ = r.Close()
+	}()
+
+	fn()
+}
+
+func executeRunAction(t *testing.T, action runAction, mock *mockRunActionService, args []string, stdin string) (string, error) {
+	t.Helper()
+
+	cmd := newCmdRunActionWith(action, func() (runActionService, error) {
+		return mock, nil
+	})
+	cmd.SetArgs(args)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	var err error
+	withStdin(t, stdin, func() {
+		err = cmd.Execute()
+	})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+func TestRunAction_ConfirmYes(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunActionService{run: newTestPlannedRun()}
+	out, err := executeRunAction(t, runApplyAction, mock, []string{"run-abc123", "--comment", "LGTM"}, "y\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mock.called != "apply" || mock.runID != "run-abc123" || mock.comment != "LGTM" {
+		t.Errorf("unexpected call: %q %q %q", mock.called, mock.runID, mock.comment)
+	}
+	for _, want := range []string{"+2 ~1 -3", "Apply run run-abc123? [y/N]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunAction_ConfirmNo(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunActionService{run: newTestPlannedRun()}
+	_, err := executeRunAction(t, runDiscardAction, mock, []string{"run-abc123"}, "\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.called != "" {
+		t.Errorf("expected no action, got %q", mock.called)
+	}
+}
+
+func TestRunAction_Yes(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunActionService{run: newTestPlannedRun()}
+	out, err := executeRunAction(t, runDiscardAction, mock, []string{"run-abc123", "--yes"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.called != "discard" {
+		t.Errorf("expected discard, got %q", mock.called)
+	}
+	if mock.comment != "" {
+		t.Errorf("expected empty comment, got %q", mock.comment)
+	}
+	if out != "" {
+		t.Errorf("expected no output with --yes, got:\n%s", out)
+	}
+}
+
+func TestRunAction_LatestRunFromWorkspace(t *testing.T) {
+	viper.Reset()
+	viper.Set("org", "test-org")
+
+	r := newTestPlannedRun()
+	r.Status = tfe.RunApplying
+	r.Actions = &tfe.RunActions{IsCancelable: true}
+	mock := &mockRunActionService{
+		run:     r,
+		runList: &tfe.RunList{Items: []*tfe.Run{{ID: "run-abc123"}}},
+	}
+
+	_, err := executeRunAction(t, runCancelAction, mock, []string{"-w", "my-ws", "-y"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.called != "cancel" || mock.runID != "run-abc123" {
+		t.Errorf("unexpected call: %q %q", mock.called, mock.runID)
+	}
+}
+
+func TestRunAction_NotAllowed(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunActionService{run: newTestPlannedRun()}
+	_, err := executeRunAction(t, runForceCancelAction, mock, []string{"run-abc123", "--yes"}, "")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "cannot be force-canceled (status: planned)") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if mock.called != "" {
+		t.Errorf("expected no action, got %q", mock.called)
+	}
+}
+
+func TestRunAction_APIError(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunActionService{run: newTestPlannedRun(), actionErr: fmt.Errorf("conflict")}
+	_, err := executeRunAction(t, runApplyAction, mock, []string{"run-abc123", "--yes"}, "")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), `failed to apply run "run-abc123": conflict`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunAction_FlagValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		org  string
+		want string
+	}{
+		{"no target", []string{}, "test-org", "either run-id, --pr, or --workspace/-w is required"},
+		{"run-id and pr", []string{"run-abc123", "--pr", "1", "--repo", "owner/repo"}, "test-org", "cannot specify both run-id and --pr"},
+		{"invalid repo", []string{"--pr", "1", "--repo", "invalid"}, "test-org", "--repo must be in format 'owner/repo'"},
+		{"workspace without org", []string{"-w", "my-ws"}, "", "organization is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			if tt.org != "" {
+				viper.Set("org", tt.org)
+			}
+
+			mock := &mockRunActionService{run: newTestPlannedRun()}
+			_, err := executeRunAction(t, runApplyAction, mock, tt.args, "")
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
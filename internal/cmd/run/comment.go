@@ -0,0 +1,336 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/output"
+)
+
+// prCommentMarker identifies the comment that run comment keeps up to date.
+const prCommentMarker = "<!-- hcpt:run-comment -->"
+
+// maxPRCommentLength is the maximum number of characters of a GitHub comment.
+const maxPRCommentLength = 65536
+
+// runCommentService combines RunService, PlanService, and PolicyService for run summaries.
+type runCommentService interface {
+	client.RunService
+	client.PlanService
+	client.PolicyService
+}
+
+// prCommentService finds the runs of a pull request and comments on it.
+type prCommentService interface {
+	prRunsService
+	UpsertPRComment(ctx context.Context, owner, repo string, prNumber int, marker, body string) (*client.PRComment, error)
+}
+
+type runCommentClientFactory func() (runCommentService, error)
+
+type prCommentClientFactory func() (prCommentService, error)
+
+func defaultRunCommentClientFactory() (runCommentService, error) {
+	return client.NewClientWrapper()
+}
+
+func defaultPRCommentClientFactory() (prCommentService, error) {
+	return client.NewGitHubClientWrapper()
+}
+
+type prCommentJSON struct {
+	URL     string `json:"url"`
+	Created bool   `json:"created"`
+	Runs    int    `json:"runs"`
+}
+
+// runSummary is a run linked from a pull request with the details shown in
+// its comment.
+type runSummary struct {
+	Status   client.StatusRun
+	Run      *tfe.Run
+	Changes  []resourceChange
+	Policies []policyResult
+}
+
+func newCmdRunComment() *cobra.Command {
+	return newCmdRunCommentWith(defaultRunCommentClientFactory, defaultPRCommentClientFactory)
+}
+
+func newCmdRunCommentWith(clientFn runCommentClientFactory, prClientFn prCommentClientFactory) *cobra.Command {
+	var prNumber int
+	var repoFullName string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "comment",
+		Short: "Post a summary of the runs of a pull request as a comment",
+		Long: `Post a Markdown summary of every HCP Terraform run reported on the head
+commit of a GitHub pull request: the status and plan changes of each
+workspace, with the resource changes, policy results, and cost estimate.
+
+The summary is kept in a single comment, starting with a hidden marker, that
+is created on the first call and updated on later calls. Only comments
+written by the same GitHub user are updated. Use --dry-run to
+print the Markdown without posting it.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if prNumber <= 0 {
+				return fmt.Errorf("--pr is required")
+			}
+
+			if repoFullName == "" {
+				// Try to auto-detect repository from Git remote
+				detectedRepo, err := client.DetectGitHubRepository(context.Background())
+				if err != nil {
+					return err
+				}
+				repoFullName = detectedRepo
+			}
+
+			owner, repo, ok := strings.Cut(repoFullName, "/")
+			if !ok {
+				return fmt.Errorf("--repo must be in format 'owner/repo'")
+			}
+
+			prSvc, err := prClientFn()
+			if err != nil {
+				return err
+			}
+			svc, err := clientFn()
+			if err != nil {
+				return err
+			}
+			return runRunComment(svc, prSvc, owner, repo, prNumber, dryRun)
+		},
+	}
+
+	cmd.Flags().IntVarP(&prNumber, "pr", "p", 0, "GitHub pull request number (required)")
+	cmd.Flags().StringVarP(&repoFullName, "repo", "r", "", "GitHub repository (owner/repo)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the comment instead of posting it")
+
+	return cmd
+}
+
+func runRunComment(svc runCommentService, prSvc prCommentService, owner, repo string, prNumber int, dryRun bool) error {
+	ctx := context.Background()
+
+	statusRuns, err := prSvc.ListRunsFromPR(ctx, owner, repo, prNumber)
+	if err != nil {
+		return err
+	}
+	if len(statusRuns) == 0 {
+		return fmt.Errorf("no HCP Terraform run found in PR #%d", prNumber)
+	}
+
+	summaries := make([]runSummary, 0, len(statusRuns))
+	for _, sr := range statusRuns {
+		r, err := svc.ReadRun(ctx, sr.RunID)
+		if err != nil {
+			return fmt.Errorf("failed to read run %q: %w", sr.RunID, err)
+		}
+		summaries = append(summaries, fetchRunSummary(ctx, svc, sr, r))
+	}
+
+	body := prCommentBody(summaries, maxPRCommentLength-utf8.RuneCountInString(prCommentMarker)-1)
+
+	if dryRun {
+		_, _ = fmt.Fprint(os.Stdout, body)
+		return nil
+	}
+
+	comment, err := prSvc.UpsertPRComment(ctx, owner, repo, prNumber, prCommentMarker, body)
+	if err != nil {
+		return err
+	}
+
+	if viper.GetBool("json") {
+		return output.PrintJSON(os.Stdout, prCommentJSON{URL: comment.URL, Created: comment.Created, Runs: len(summaries)})
+	}
+	action := "Updated"
+	if comment.Created {
+		action = "Created"
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "%s comment: %s\n", action, comment.URL)
+	return nil
+}
+
+// fetchRunSummary fetches the resource changes and policy results of a run.
+// Errors are ignored so that the run is summarized without those details.
+func fetchRunSummary(ctx context.Context, svc runCommentService, sr client.StatusRun, r *tfe.Run) runSummary {
+	summary := runSummary{Status: sr, Run: r}
+	if r.Plan != nil && r.HasChanges {
+		planJSONBytes, err := svc.ReadPlanJSONOutput(ctx, r.Plan.ID)
+		if err == nil {
+			summary.Changes, _ = extractResourceChanges(planJSONBytes)
+		}
+	}
+	summary.Policies, _ = collectPolicyResults(ctx, svc, r)
+	return summary
+}
+
+// prCommentBody renders the summary of the runs within maxLength characters,
+// listing fewer resource changes per run until it fits. If the summary is
+// still too long without them, it is cut at maxLength.
+func prCommentBody(summaries []runSummary, maxLength int) string {
+	maxChanges := -1
+	for {
+		var body strings.Builder
+		renderPRComment(&body, summaries, maxChanges)
+		if utf8.RuneCountInString(body.String()) <= maxLength {
+			return body.String()
+		}
+		if maxChanges == 0 {
+			break
+		}
+		if maxChanges < 0 {
+			for _, s := range summaries {
+				maxChanges = max(maxChanges, len(s.Changes))
+			}
+		}
+		maxChanges /= 2
+	}
+
+	const notice = "\n\n… summary truncated\n"
+	var body strings.Builder
+	renderPRComment(&body, summaries, 0)
+	runes := []rune(body.String())
+	return string(runes[:maxLength-utf8.RuneCountInString(notice)]) + notice
+}
+
+// renderPRComment writes the Markdown summary of the runs of a pull request:
+// a table of all runs followed by the details of each run, listing at most
+// maxChanges resource changes per run unless maxChanges is negative.
+func renderPRComment(w io.Writer, summaries []runSummary, maxChanges int) {
+	_, _ = fmt.Fprintln(w, "## HCP Terraform")
+	_, _ = fmt.Fprintln(w, "")
+	_, _ = fmt.Fprintln(w, "| Workspace | Run | Status | Changes |")
+	_, _ = fmt.Fprintln(w, "|-----------|-----|--------|---------|")
+	for _, s := range summaries {
+		_, _ = fmt.Fprintf(w, "| %s | [%s](%s) | %s | %s |\n",
+			markdownCell(s.workspace()), s.Run.ID, s.Status.URL, s.Run.Status, planChangeCounts(s.Run))
+	}
+
+	for _, s := range summaries {
+		if len(s.Changes) == 0 && len(s.Policies) == 0 && !hasCostEstimate(s.Run) {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n### %s\n", s.workspace())
+
+		if len(s.Changes) > 0 {
+			noun := "resource changes"
+			if len(s.Changes) == 1 {
+				noun = "resource change"
+			}
+			_, _ = fmt.Fprintf(w, "\n<details>\n<summary>%d %s</summary>\n\n", len(s.Changes), noun)
+			_, _ = fmt.Fprintln(w, "| Action | Resource |")
+			_, _ = fmt.Fprintln(w, "|--------|----------|")
+			shown := s.Changes
+			if maxChanges >= 0 && len(shown) > maxChanges {
+				shown = shown[:maxChanges]
+			}
+			for _, rc := range shown {
+				_, _ = fmt.Fprintf(w, "| %s | `%s` |\n", changeAction(rc.Actions), markdownCell(rc.Address))
+			}
+			if more := len(s.Changes) - len(shown); more > 0 {
+				_, _ = fmt.Fprintf(w, "\n… and %d more\n", more)
+			}
+			_, _ = fmt.Fprintln(w, "\n</details>")
+		}
+
+		if len(s.Policies) > 0 {
+			_, _ = fmt.Fprintf(w, "\n**Policies:** %s\n\n", policyCounts(s.Policies))
+			_, _ = fmt.Fprintln(w, "| Policy Set | Policy | Enforcement | Result |")
+			_, _ = fmt.Fprintln(w, "|------------|--------|-------------|--------|")
+			for _, res := range s.Policies {
+				level := res.EnforcementLevel
+				if level == "" {
+					level = "-"
+				}
+				_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s |\n", markdownCell(res.PolicySet), markdownCell(res.Policy), level, res.Status)
+			}
+		}
+
+		if hasCostEstimate(s.Run) {
+			_, _ = fmt.Fprintf(w, "\n**Cost Estimate:** %s\n", formatCostSummary(s.Run.CostEstimate))
+		}
+	}
+}
+
+func (s runSummary) workspace() string {
+	return statusRunWorkspace(s.Status)
+}
+
+// statusRunWorkspace returns the workspace name of a status run, or the status
+// context when the run URL does not include it.
+func statusRunWorkspace(sr client.StatusRun) string {
+	if sr.Workspace != "" {
+		return sr.Workspace
+	}
+	return sr.Context
+}
+
+// planChangeCounts formats the plan changes of a run as "+1 ~2 -3", or "-"
+// while the plan is in progress.
+func planChangeCounts(r *tfe.Run) string {
+	if r.Plan == nil || (!isTerminalStatus(r.Status) && !isPlanFinished(r)) {
+		return "-"
+	}
+	return fmt.Sprintf("+%d ~%d -%d", r.Plan.ResourceAdditions, r.Plan.ResourceChanges, r.Plan.ResourceDestructions)
+}
+
+// changeAction names the action of a resource change, reporting a delete and
+// create pair as a replacement.
+func changeAction(actions []string) string {
+	if len(actions) == 2 && actions[0] != actions[1] &&
+		(actions[0] == "delete" || actions[0] == "create") && (actions[1] == "delete" || actions[1] == "create") {
+		return "replace"
+	}
+	return formatActions(actions)
+}
+
+// policyCounts summarizes policy results by status, e.g. "2 passed, 1 failed".
+func policyCounts(results []policyResult) string {
+	var statuses []string
+	counts := make(map[string]int)
+	for _, res := range results {
+		if counts[res.Status] == 0 {
+			statuses = append(statuses, res.Status)
+		}
+		counts[res.Status]++
+	}
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatCostSummary formats a cost estimate on one line, e.g.
+// "+$2.00/month ($10.00 → $12.00)".
+func formatCostSummary(ce *tfe.CostEstimate) string {
+	switch ce.Status {
+	case tfe.CostEstimateFinished:
+		return fmt.Sprintf("%s/month (%s → %s)", formatCostDelta(ce.DeltaMonthlyCost), formatCost(ce.PriorMonthlyCost), formatCost(ce.ProposedMonthlyCost))
+	case tfe.CostEstimateErrored:
+		return fmt.Sprintf("errored (%s)", ce.ErrorMessage)
+	default:
+		return string(ce.Status)
+	}
+}
+
+// markdownCell escapes a value for a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
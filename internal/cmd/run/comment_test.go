@@ -0,0 +1,352 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+)
+
+type mockRunCommentService struct {
+	runs     map[string]*tfe.Run
+	planJSON map[string][]byte
+	outcomes []*tfe.PolicySetOutcome
+}
+
+func (m *mockRunCommentService) ListRuns(_ context.Context, _ string, _ *tfe.RunListOptions) (*tfe.RunList, error) {
+	return nil, nil
+}
+
+func (m *mockRunCommentService) ReadRun(_ context.Context, runID string) (*tfe.Run, error) {
+	r, ok := m.runs[runID]
+	if !ok {
+		return nil, fmt.Errorf("run not found")
+	}
+	return r, nil
+}
+
+func (m *mockRunCommentService) ReadRunWithApply(ctx context.Context, runID string) (*tfe.Run, error) {
+	return m.ReadRun(ctx, runID)
+}
+
+func (m *mockRunCommentService) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, nil
+}
+
+func (m *mockRunCommentService) ReadPlanJSONOutput(_ context.Context, planID string) ([]byte, error) {
+	b, ok := m.planJSON[planID]
+	if !ok {
+		return nil, fmt.Errorf("plan not found")
+	}
+	return b, nil
+}
+
+func (m *mockRunCommentService) ReadPlanLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, nil
+}
+
+func (m *mockRunCommentService) ListPolicyChecks(_ context.Context, _ string) ([]*tfe.PolicyCheck, error) {
+	return nil, nil
+}
+
+func (m *mockRunCommentService) ReadPolicyCheckLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, nil
+}
+
+func (m *mockRunCommentService) ListPolicyEvaluations(_ context.Context, _ string) ([]*tfe.PolicyEvaluation, error) {
+	return []*tfe.PolicyEvaluation{{ID: "poleval-1"}}, nil
+}
+
+func (m *mockRunCommentService) ListPolicySetOutcomes(_ context.Context, _ string) ([]*tfe.PolicySetOutcome, error) {
+	return m.outcomes, nil
+}
+
+type mockPRCommentService struct {
+	runs    []client.StatusRun
+	listErr error
+	created bool
+
+	// Recorded by UpsertPRComment
+	marker string
+	body   string
+}
+
+func (m *mockPRCommentService) ListRunsFromPR(_ context.Context, _, _ string, _ int) ([]client.StatusRun, error) {
+	return m.runs, m.listErr
+}
+
+func (m *mockPRCommentService) UpsertPRComment(_ context.Context, _, _ string, _ int, marker, body string) (*client.PRComment, error) {
+	m.marker, m.body = marker, body
+	return &client.PRComment{URL: "https://github.com/owner/repo/pull/42#issuecomment-1", Created: m.created}, nil
+}
+
+func newTestRunCommentMocks() (*mockRunCommentService, *mockPRCommentService) {
+	svc := &mockRunCommentService{
+		runs: map[string]*tfe.Run{
+			"run-aaaa": {
+				ID:         "run-aaaa",
+				Status:     tfe.RunPlanned,
+				HasChanges: true,
+				Plan:       &tfe.Plan{ID: "plan-aaaa", Status: tfe.PlanFinished, ResourceAdditions: 1, ResourceChanges: 0, ResourceDestructions: 1},
+				CostEstimate: &tfe.CostEstimate{
+					Status:              tfe.CostEstimateFinished,
+					PriorMonthlyCost:    "10",
+					ProposedMonthlyCost: "12.5",
+					DeltaMonthlyCost:    "2.5",
+				},
+				TaskStages: []*tfe.TaskStage{{ID: "ts-1"}},
+				CreatedAt:  time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC),
+			},
+			"run-bbbb": {
+				ID:        "run-bbbb",
+				Status:    tfe.RunPlanning,
+				Plan:      &tfe.Plan{ID: "plan-bbbb", Status: tfe.PlanRunning},
+				CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		planJSON: map[string][]byte{
+			"plan-aaaa": []byte(`{"resource_changes":[
+				{"address":"aws_instance.web","type":"aws_instance","change":{"actions":["delete","create"]}},
+				{"address":"aws_s3_bucket.logs","type":"aws_s3_bucket","change":{"actions":["create"]}},
+				{"address":"aws_iam_role.ci","type":"aws_iam_role","change":{"actions":["no-op"]}}
+			]}`),
+		},
+		outcomes: []*tfe.PolicySetOutcome{{
+			PolicySetName: "security",
+			Outcomes: []tfe.Outcome{
+				{PolicyName: "no-public-buckets", EnforcementLevel: tfe.EnforcementMandatory, Status: "passed"},
+				{PolicyName: "tags-required", EnforcementLevel: tfe.EnforcementAdvisory, Status: "failed"},
+			},
+		}},
+	}
+	prSvc := &mockPRCommentService{
+		runs: []client.StatusRun{
+			{Context: "HCP Terraform / my-org / ws-a", Workspace: "ws-a", RunID: "run-aaaa", URL: "https://app.terraform.io/app/my-org/workspaces/ws-a/runs/run-aaaa"},
+			{Context: "HCP Terraform / my-org / ws-b", Workspace: "ws-b", RunID: "run-bbbb", URL: "https://app.terraform.io/app/my-org/workspaces/ws-b/runs/run-bbbb"},
+		},
+	}
+	return svc, prSvc
+}
+
+func TestRunComment(t *testing.T) {
+	viper.Reset()
+	svc, prSvc := newTestRunCommentMocks()
+	prSvc.created = true
+
+	out, err := captureStdout(t, func() error {
+		return runRunComment(svc, prSvc, "owner", "repo", 42, false)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if prSvc.marker != prCommentMarker {
+		t.Errorf("expected marker %q, got %q", prCommentMarker, prSvc.marker)
+	}
+	for _, want := range []string{
+		"| ws-a | [run-aaaa](https://app.terraform.io/app/my-org/workspaces/ws-a/runs/run-aaaa) | planned | +1 ~0 -1 |",
+		"| ws-b | [run-bbbb](https://app.terraform.io/app/my-org/workspaces/ws-b/runs/run-bbbb) | planning | - |",
+		"### ws-a",
+		"<summary>2 resource changes</summary>",
+		"| replace | `aws_instance.web` |",
+		"| create | `aws_s3_bucket.logs` |",
+		"**Policies:** 1 passed, 1 failed",
+		"| security | tags-required | advisory | failed |",
+		"**Cost Estimate:** +$2.50/month ($10.00 → $12.50)",
+	} {
+		if !strings.Contains(prSvc.body, want) {
+			t.Errorf("expected comment to contain %q, got:\n%s", want, prSvc.body)
+		}
+	}
+	if strings.Contains(prSvc.body, "aws_iam_role.ci") {
+		t.Errorf("expected no-op changes to be excluded, got:\n%s", prSvc.body)
+	}
+	if strings.Contains(prSvc.body, "### ws-b") {
+		t.Errorf("expected no details for a run without changes, got:\n%s", prSvc.body)
+	}
+	if out != "Created comment: https://github.com/owner/repo/pull/42#issuecomment-1\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestRunComment_JSON(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+	defer viper.Reset()
+	svc, prSvc := newTestRunCommentMocks()
+
+	out, err := captureStdout(t, func() error {
+		return runRunComment(svc, prSvc, "owner", "repo", 42, false)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got prCommentJSON
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("failed to parse JSON output %q: %v", out, err)
+	}
+	if got.Created || got.Runs != 2 || got.URL == "" {
+		t.Errorf("unexpected JSON output: %+v", got)
+	}
+}
+
+func TestRunComment_DryRun(t *testing.T) {
+	viper.Reset()
+	svc, prSvc := newTestRunCommentMocks()
+
+	out, err := captureStdout(t, func() error {
+		return runRunComment(svc, prSvc, "owner", "repo", 42, true)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prSvc.body != "" {
+		t.Error("expected no comment to be posted with --dry-run")
+	}
+	if !strings.HasPrefix(out, "## HCP Terraform\n") || !strings.Contains(out, "| ws-a |") {
+		t.Errorf("unexpected dry-run output:\n%s", out)
+	}
+}
+
+func TestPRCommentBody_Truncated(t *testing.T) {
+	summaries := make([]runSummary, 0, 3)
+	for i, n := range []int{2, 1500, 3000} {
+		s := runSummary{
+			Status: client.StatusRun{Workspace: fmt.Sprintf("ws-%d", i), URL: "https://app.terraform.io/app/my-org/workspaces/ws/runs/run-x"},
+			Run:    &tfe.Run{ID: fmt.Sprintf("run-%d", i), Status: tfe.RunPlannedAndFinished},
+		}
+		for j := range n {
+			s.Changes = append(s.Changes, resourceChange{
+				Address: fmt.Sprintf("module.app.aws_security_group_rule.ingress[%d]", j),
+				Actions: []string{"create"},
+			})
+		}
+		summaries = append(summaries, s)
+	}
+
+	body := prCommentBody(summaries, maxPRCommentLength)
+	if n := utf8.RuneCountInString(body); n > maxPRCommentLength {
+		t.Fatalf("expected at most %d characters, got %d", maxPRCommentLength, n)
+	}
+	if !strings.Contains(body, "ingress[1]`") || strings.Contains(body, "ingress[2999]") {
+		t.Errorf("expected the resource changes to be cut short, got %d characters", len(body))
+	}
+	if !strings.Contains(body, "2 resource changes") || !strings.Contains(body, "3000 resource changes") {
+		t.Errorf("expected the summaries to keep the total counts")
+	}
+	if strings.Count(body, "… and ") != 2 || strings.Contains(body, "… and 0 ") {
+		t.Errorf("expected the truncated tables to end with the number of omitted changes")
+	}
+
+	small := prCommentBody(summaries[:1], maxPRCommentLength)
+	if strings.Contains(small, "… and") {
+		t.Errorf("expected a short summary not to be truncated:\n%s", small)
+	}
+}
+
+func TestPRCommentBody_TooManyRuns(t *testing.T) {
+	var summaries []runSummary
+	for i := range 2000 {
+		summaries = append(summaries, runSummary{
+			Status: client.StatusRun{Workspace: fmt.Sprintf("workspace-%d", i), URL: "https://app.terraform.io/app/my-org/workspaces/ws/runs/run-x"},
+			Run:    &tfe.Run{ID: fmt.Sprintf("run-%d", i), Status: tfe.RunPlanning},
+		})
+	}
+
+	body := prCommentBody(summaries, maxPRCommentLength)
+	if n := utf8.RuneCountInString(body); n > maxPRCommentLength {
+		t.Fatalf("expected at most %d characters, got %d", maxPRCommentLength, n)
+	}
+	if !strings.HasSuffix(body, "… summary truncated\n") {
+		t.Errorf("expected a truncation notice at the end of the summary")
+	}
+}
+
+func TestRunComment_NoRuns(t *testing.T) {
+	viper.Reset()
+	svc, prSvc := newTestRunCommentMocks()
+	prSvc.runs = nil
+
+	err := runRunComment(svc, prSvc, "owner", "repo", 42, false)
+	if err == nil || !strings.Contains(err.Error(), "no HCP Terraform run found in PR #42") {
+		t.Errorf("expected 'no HCP Terraform run found in PR #42' error, got: %v", err)
+	}
+}
+
+func TestRunComment_ReadRunError(t *testing.T) {
+	viper.Reset()
+	svc, prSvc := newTestRunCommentMocks()
+	prSvc.runs = append(prSvc.runs, client.StatusRun{Context: "ws-c", RunID: "run-missing"})
+
+	err := runRunComment(svc, prSvc, "owner", "repo", 42, false)
+	if err == nil || !strings.Contains(err.Error(), `failed to read run "run-missing"`) {
+		t.Errorf("expected read run error, got: %v", err)
+	}
+	if prSvc.body != "" {
+		t.Error("expected no comment to be posted when a run cannot be read")
+	}
+}
+
+func TestRunComment_Validation(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--repo", "owner/repo"}, "--pr is required"},
+		{[]string{"--pr", "42", "--repo", "repo"}, "--repo must be in format 'owner/repo'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantErr, func(t *testing.T) {
+			viper.Reset()
+
+			cmd := newCmdRunCommentWith(
+				func() (runCommentService, error) { return &mockRunCommentService{}, nil },
+				func() (prCommentService, error) { return &mockPRCommentService{}, nil },
+			)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected %q error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestChangeAction(t *testing.T) {
+	tests := []struct {
+		actions []string
+		want    string
+	}{
+		{[]string{"create"}, "create"},
+		{[]string{"delete", "create"}, "replace"},
+		{[]string{"create", "delete"}, "replace"},
+		{[]string{"update"}, "update"},
+		{nil, "-"},
+	}
+	for _, tt := range tests {
+		if got := changeAction(tt.actions); got != tt.want {
+			t.Errorf("changeAction(%v) = %q, want %q", tt.actions, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownCell(t *testing.T) {
+	if got := markdownCell("a|b\nc"); got != `a\|b c` {
+		t.Errorf("unexpected escaped cell: %q", got)
+	}
+}
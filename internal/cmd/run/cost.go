@@ -0,0 +1,81 @@
+package run
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+
+	"github.com/nnstt1/hcpt/internal/output"
+)
+
+// costEstimateJSON is the cost estimate of a run. Costs are monthly amounts in
+// USD, kept as the decimal strings returned by the API.
+type costEstimateJSON struct {
+	Status                  string `json:"status"`
+	PriorMonthlyCost        string `json:"prior_monthly_cost"`
+	ProposedMonthlyCost     string `json:"proposed_monthly_cost"`
+	DeltaMonthlyCost        string `json:"delta_monthly_cost"`
+	MatchedResourcesCount   int    `json:"matched_resources_count"`
+	UnmatchedResourcesCount int    `json:"unmatched_resources_count"`
+	ErrorMessage            string `json:"error_message,omitempty"`
+}
+
+// hasCostEstimate reports whether the cost estimate of a run was loaded.
+// Without the cost_estimate include only its ID is known.
+func hasCostEstimate(r *tfe.Run) bool {
+	return r.CostEstimate != nil && r.CostEstimate.Status != ""
+}
+
+func toCostEstimateJSON(ce *tfe.CostEstimate) *costEstimateJSON {
+	return &costEstimateJSON{
+		Status:                  string(ce.Status),
+		PriorMonthlyCost:        ce.PriorMonthlyCost,
+		ProposedMonthlyCost:     ce.ProposedMonthlyCost,
+		DeltaMonthlyCost:        ce.DeltaMonthlyCost,
+		MatchedResourcesCount:   ce.MatchedResourcesCount,
+		UnmatchedResourcesCount: ce.UnmatchedResourcesCount,
+		ErrorMessage:            ce.ErrorMessage,
+	}
+}
+
+// costEstimateKeyValues returns the cost estimate fields for table output.
+func costEstimateKeyValues(ce *tfe.CostEstimate) []output.KeyValue {
+	pairs := []output.KeyValue{
+		{Key: "Status", Value: string(ce.Status)},
+	}
+	switch ce.Status {
+	case tfe.CostEstimateFinished:
+		pairs = append(pairs,
+			output.KeyValue{Key: "Prior Monthly Cost", Value: formatCost(ce.PriorMonthlyCost)},
+			output.KeyValue{Key: "Proposed Monthly Cost", Value: formatCost(ce.ProposedMonthlyCost)},
+			output.KeyValue{Key: "Delta", Value: formatCostDelta(ce.DeltaMonthlyCost)},
+			output.KeyValue{Key: "Resources", Value: fmt.Sprintf("%d matched, %d unmatched", ce.MatchedResourcesCount, ce.UnmatchedResourcesCount)},
+		)
+	case tfe.CostEstimateErrored:
+		pairs = append(pairs, output.KeyValue{Key: "Error", Value: ce.ErrorMessage})
+	}
+	return pairs
+}
+
+// formatCost formats a cost such as "12.5" as "$12.50".
+func formatCost(s string) string {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
+
+// formatCostDelta formats a cost change with its sign, e.g. "+$1.20" or "-$0.50".
+func formatCostDelta(s string) string {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	if v < 0 {
+		return "-" + formatCost(strings.TrimPrefix(s, "-"))
+	}
+	return "+" + formatCost(s)
+}
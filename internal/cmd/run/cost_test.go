@@ -0,0 +1,87 @@
+package run
+
+import (
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+func TestFormatCost(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"12.5", "$12.50"},
+		{"0", "$0.00"},
+		{"1234.567", "$1234.57"},
+		{"n/a", "n/a"},
+	}
+	for _, tt := range tests {
+		if got := formatCost(tt.in); got != tt.want {
+			t.Errorf("formatCost(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCostDelta(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1.2", "+$1.20"},
+		{"-0.5", "-$0.50"},
+		{"0", "+$0.00"},
+		{"n/a", "n/a"},
+	}
+	for _, tt := range tests {
+		if got := formatCostDelta(tt.in); got != tt.want {
+			t.Errorf("formatCostDelta(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCostEstimateKeyValues(t *testing.T) {
+	finished := costEstimateKeyValues(&tfe.CostEstimate{
+		Status:                  tfe.CostEstimateFinished,
+		PriorMonthlyCost:        "10",
+		ProposedMonthlyCost:     "12.5",
+		DeltaMonthlyCost:        "2.5",
+		MatchedResourcesCount:   3,
+		UnmatchedResourcesCount: 1,
+	})
+	want := map[string]string{
+		"Status":                "finished",
+		"Prior Monthly Cost":    "$10.00",
+		"Proposed Monthly Cost": "$12.50",
+		"Delta":                 "+$2.50",
+		"Resources":             "3 matched, 1 unmatched",
+	}
+	if len(finished) != len(want) {
+		t.Fatalf("expected %d pairs, got %d: %v", len(want), len(finished), finished)
+	}
+	for _, kv := range finished {
+		if want[kv.Key] != kv.Value {
+			t.Errorf("%s = %q, want %q", kv.Key, kv.Value, want[kv.Key])
+		}
+	}
+
+	errored := costEstimateKeyValues(&tfe.CostEstimate{
+		Status:       tfe.CostEstimateErrored,
+		ErrorMessage: "pricing unavailable",
+	})
+	if len(errored) != 2 || errored[1].Key != "Error" || errored[1].Value != "pricing unavailable" {
+		t.Errorf("unexpected pairs for errored estimate: %v", errored)
+	}
+}
+
+func TestHasCostEstimate(t *testing.T) {
+	if hasCostEstimate(&tfe.Run{}) {
+		t.Error("expected false for run without cost estimate")
+	}
+	if hasCostEstimate(&tfe.Run{CostEstimate: &tfe.CostEstimate{ID: "ce-123"}}) {
+		t.Error("expected false for cost estimate that was not included")
+	}
+	if !hasCostEstimate(&tfe.Run{CostEstimate: &tfe.CostEstimate{ID: "ce-123", Status: tfe.CostEstimatePending}}) {
+		t.Error("expected true for included cost estimate")
+	}
+}
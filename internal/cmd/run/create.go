@@ -0,0 +1,186 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/nnstt1/hcpt/internal/client"
+)
+
+// runCreateService combines the services needed to create a run and watch it.
+type runCreateService interface {
+	client.RunService
+	client.WorkspaceService
+	client.PlanService
+	client.PolicyService
+	client.TaskService
+}
+
+type runCreateClientFactory func() (runCreateService, error)
+
+func defaultRunCreateClientFactory() (runCreateService, error) {
+	return client.NewClientWrapper()
+}
+
+// runCreateOptions holds the flags for run create.
+type runCreateOptions struct {
+	Message     string
+	PlanOnly    bool
+	RefreshOnly bool
+	Destroy     bool
+	Targets     []string
+	Replaces    []string
+	Vars        []string
+	AutoApply   *bool
+	Watch       bool
+}
+
+func newCmdRunCreate() *cobra.Command {
+	return newCmdRunCreateWith(defaultRunCreateClientFactory)
+}
+
+func newCmdRunCreateWith(clientFn runCreateClientFactory) *cobra.Command {
+	var workspaceName string
+	var autoApply bool
+	var opts runCreateOptions
+
+	cmd := &cobra.Command{
+		Use:          "create",
+		Short:        "Queue a new run for a workspace",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			org := viper.GetString("org")
+			if org == "" {
+				return errOrgRequired
+			}
+			if workspaceName == "" {
+				return errWorkspaceRequired
+			}
+			if cmd.Flags().Changed("auto-apply") {
+				opts.AutoApply = &autoApply
+			}
+
+			svc, err := clientFn()
+			if err != nil {
+				return err
+			}
+			return runRunCreate(svc, org, workspaceName, opts, 5*time.Second)
+		},
+	}
+
+	cmd.Flags().StringVarP(&workspaceName, "workspace", "w", "", "workspace name (required)")
+	cmd.Flags().StringVarP(&opts.Message, "message", "m", "", "run message")
+	cmd.Flags().BoolVar(&opts.PlanOnly, "plan-only", false, "create a speculative plan that cannot be applied")
+	cmd.Flags().BoolVar(&opts.RefreshOnly, "refresh-only", false, "create a refresh-only run")
+	cmd.Flags().BoolVar(&opts.Destroy, "destroy", false, "create a destroy run")
+	cmd.Flags().StringArrayVar(&opts.Targets, "target", nil, "resource address to target (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Replaces, "replace", nil, "resource address to replace (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Vars, "var", nil, "run variable as key=value (can be repeated)")
+	cmd.Flags().BoolVar(&autoApply, "auto-apply", false, "apply automatically after a successful plan (overrides workspace setting)")
+	cmd.Flags().BoolVarP(&opts.Watch, "watch", "W", false, "watch run status until completion")
+
+	cmd.MarkFlagsMutuallyExclusive("refresh-only", "destroy")
+	cmd.MarkFlagsMutuallyExclusive("plan-only", "auto-apply")
+
+	return cmd
+}
+
+func runRunCreate(svc runCreateService, org, workspaceName string, opts runCreateOptions, pollInterval time.Duration) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	createOpts, err := buildRunCreateOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	ws, err := svc.ReadWorkspace(ctx, org, workspaceName)
+	if err != nil {
+		return fmt.Errorf("failed to read workspace %q: %w", workspaceName, err)
+	}
+	createOpts.Workspace = ws
+
+	r, err := svc.CreateRun(ctx, createOpts)
+	if err != nil {
+		return fmt.Errorf("failed to create run: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Created run %s\n", r.ID)
+
+	if !opts.Watch {
+		return displayRun(r, runDetails{})
+	}
+
+	// CreateRun does not include the plan, so use ReadRun before watching
+	runID := r.ID
+	r, err = svc.ReadRun(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("failed to read run %q: %w", runID, err)
+	}
+	return watchRun(ctx, svc, runID, r, runShowOptions{}, pollInterval)
+}
+
+// buildRunCreateOptions converts command flags into tfe.RunCreateOptions.
+func buildRunCreateOptions(opts runCreateOptions) (tfe.RunCreateOptions, error) {
+	createOpts := tfe.RunCreateOptions{
+		TargetAddrs:  opts.Targets,
+		ReplaceAddrs: opts.Replaces,
+		AutoApply:    opts.AutoApply,
+	}
+	if opts.Message != "" {
+		createOpts.Message = tfe.String(opts.Message)
+	}
+	if opts.PlanOnly {
+		createOpts.PlanOnly = tfe.Bool(true)
+	}
+	if opts.RefreshOnly {
+		createOpts.RefreshOnly = tfe.Bool(true)
+	}
+	if opts.Destroy {
+		createOpts.IsDestroy = tfe.Bool(true)
+	}
+
+	for _, v := range opts.Vars {
+		key, value, found := strings.Cut(v, "=")
+		if !found || key == "" {
+			return tfe.RunCreateOptions{}, fmt.Errorf("invalid --var %q: must be in format key=value", v)
+		}
+		createOpts.Variables = append(createOpts.Variables, &tfe.RunVariable{
+			Key:   key,
+			Value: runVariableValue(value),
+		})
+	}
+
+	return createOpts, nil
+}
+
+// numberPattern matches canonical HCL number literals. Values with leading
+// zeros, such as account IDs, are not numbers: Terraform would drop the zeros
+// when converting them back to strings.
+var numberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// runVariableValue converts a --var value into the HCL expression expected by
+// the runs API. Numbers, booleans, lists, maps and already-quoted strings are
+// passed through; anything else is encoded as an HCL string.
+func runVariableValue(value string) string {
+	if value == "true" || value == "false" {
+		return value
+	}
+	if numberPattern.MatchString(value) {
+		return value
+	}
+	if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") || strings.HasPrefix(value, `"`) {
+		return value
+	}
+	return string(hclwrite.TokensForValue(cty.StringVal(value)).Bytes())
+}
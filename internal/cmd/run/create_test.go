@@ -0,0 +1,385 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/viper"
+)
+
+type mockRunCreateService struct {
+	workspace    *tfe.Workspace
+	workspaceErr error
+	created      *tfe.Run
+	createErr    error
+	createOpts   tfe.RunCreateOptions
+	runs         []*tfe.Run // runs returned by ReadRun in sequence
+	readCount    int
+}
+
+func (m *mockRunCreateService) ListRuns(_ context.Context, _ string, _ *tfe.RunListOptions) (*tfe.RunList, error) {
+	return nil, fmt.Errorf("ListRuns not implemented in mockRunCreateService")
+}
+
+func (m *mockRunCreateService) ReadRun(_ context.Context, _ string) (*tfe.Run, error) {
+	if len(m.runs) == 0 {
+		return nil, fmt.Errorf("run not found")
+	}
+	if m.readCount >= len(m.runs) {
+		return m.runs[len(m.runs)-1], nil
+	}
+	r := m.runs[m.readCount]
+	m.readCount++
+	return r, nil
+}
+
+func (m *mockRunCreateService) ReadRunWithApply(ctx context.Context, runID string) (*tfe.Run, error) {
+	return m.ReadRun(ctx, runID)
+}
+
+func (m *mockRunCreateService) CreateRun(_ context.Context, opts tfe.RunCreateOptions) (*tfe.Run, error) {
+	m.createOpts = opts
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	return m.created, nil
+}
+
+func (m *mockRunCreateService) ListWorkspaces(_ context.Context, _ string, _ *tfe.WorkspaceListOptions) (*tfe.WorkspaceList, error) {
+	return nil, nil
+}
+
+func (m *mockRunCreateService) ReadWorkspace(_ context.Context, _, _ string) (*tfe.Workspace, error) {
+	if m.workspaceErr != nil {
+		return nil, m.workspaceErr
+	}
+	return m.workspace, nil
+}
+
+func (m *mockRunCreateService) ReadPlanJSONOutput(_ context.Context, _ string) ([]byte, error) {
+	return nil, fmt.Errorf("ReadPlanJSONOutput not implemented in mockRunCreateService")
+}
+
+func (m *mockRunCreateService) ReadPlanLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, fmt.Errorf("ReadPlanLogs not implemented in mockRunCreateService")
+}
+
+func (m *mockRunCreateService) ListPolicyChecks(_ context.Context, _ string) ([]*tfe.PolicyCheck, error) {
+	return nil, nil
+}
+
+func (m *mockRunCreateService) ReadPolicyCheckLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, fmt.Errorf("ReadPolicyCheckLogs not implemented in mockRunCreateService")
+}
+
+func (m *mockRunCreateService) ListPolicyEvaluations(_ context.Context, _ string) ([]*tfe.PolicyEvaluation, error) {
+	return nil, nil
+}
+
+func (m *mockRunCreateService) ListPolicySetOutcomes(_ context.Context, _ string) ([]*tfe.PolicySetOutcome, error) {
+	return nil, nil
+}
+
+func (m *mockRunCreateService) ListTaskStages(_ context.Context, _ string) ([]*tfe.TaskStage, error) {
+	return nil, nil
+}
+
+func newTestRunCreateMock() *mockRunCreateService {
+	return &mockRunCreateService{
+		workspace: &tfe.Workspace{ID: "ws-abc123", Name: "my-ws"},
+		created: &tfe.Run{
+			ID:        "run-new123",
+			Status:    tfe.RunPending,
+			Message:   "Queued from hcpt",
+			CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestRunCreate_Flags(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+	viper.Set("org", "test-org")
+
+	mock := newTestRunCreateMock()
+	cmd := newCmdRunCreateWith(func() (runCreateService, error) {
+		return mock, nil
+	})
+	cmd.SetArgs([]string{
+		"-w", "my-ws",
+		"-m", "Queued from hcpt",
+		"--plan-only",
+		"--destroy",
+		"--target", "aws_instance.a",
+		"--target", "aws_instance.b",
+		"--replace", "aws_instance.c",
+		"--var", "region=us-east-1",
+		"--var", "replicas=3",
+	})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := cmd.Execute()
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := mock.createOpts
+	if opts.Workspace == nil || opts.Workspace.ID != "ws-abc123" {
+		t.Errorf("expected workspace ws-abc123, got %+v", opts.Workspace)
+	}
+	if opts.Message == nil || *opts.Message != "Queued from hcpt" {
+		t.Errorf("expected message to be set, got %v", opts.Message)
+	}
+	if opts.PlanOnly == nil || !*opts.PlanOnly {
+		t.Error("expected PlanOnly to be true")
+	}
+	if opts.IsDestroy == nil || !*opts.IsDestroy {
+		t.Error("expected IsDestroy to be true")
+	}
+	if opts.RefreshOnly != nil {
+		t.Errorf("expected RefreshOnly to be unset, got %v", *opts.RefreshOnly)
+	}
+	if opts.AutoApply != nil {
+		t.Errorf("expected AutoApply to be unset, got %v", *opts.AutoApply)
+	}
+	if strings.Join(opts.TargetAddrs, ",") != "aws_instance.a,aws_instance.b" {
+		t.Errorf("unexpected target addrs: %v", opts.TargetAddrs)
+	}
+	if strings.Join(opts.ReplaceAddrs, ",") != "aws_instance.c" {
+		t.Errorf("unexpected replace addrs: %v", opts.ReplaceAddrs)
+	}
+	if len(opts.Variables) != 2 {
+		t.Fatalf("expected 2 variables, got %d", len(opts.Variables))
+	}
+	if opts.Variables[0].Key != "region" || opts.Variables[0].Value != `"us-east-1"` {
+		t.Errorf("unexpected first variable: %+v", opts.Variables[0])
+	}
+	if opts.Variables[1].Key != "replicas" || opts.Variables[1].Value != "3" {
+		t.Errorf("unexpected second variable: %+v", opts.Variables[1])
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+
+	var result runShowJSON
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v\noutput: %s", err, buf.String())
+	}
+	if result.ID != "run-new123" || result.Status != "pending" {
+		t.Errorf("unexpected JSON output: %+v", result)
+	}
+}
+
+func TestRunCreate_AutoApply(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want *bool
+	}{
+		{[]string{"-w", "my-ws"}, nil},
+		{[]string{"-w", "my-ws", "--auto-apply"}, tfe.Bool(true)},
+		{[]string{"-w", "my-ws", "--auto-apply=false"}, tfe.Bool(false)},
+	} {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			viper.Reset()
+			viper.Set("json", true)
+			viper.Set("org", "test-org")
+
+			mock := newTestRunCreateMock()
+			cmd := newCmdRunCreateWith(func() (runCreateService, error) {
+				return mock, nil
+			})
+			cmd.SetArgs(tt.args)
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := cmd.Execute()
+
+			_ = w.Close()
+			os.Stdout = oldStdout
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := mock.createOpts.AutoApply
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("expected AutoApply unset, got %v", *got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("expected AutoApply=%v, got %v", *tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRunCreate_InvalidVar(t *testing.T) {
+	viper.Reset()
+	viper.Set("org", "test-org")
+
+	mock := newTestRunCreateMock()
+	cmd := newCmdRunCreateWith(func() (runCreateService, error) {
+		return mock, nil
+	})
+	cmd.SetArgs([]string{"-w", "my-ws", "--var", "novalue"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error for invalid --var")
+	}
+	if !strings.Contains(err.Error(), "key=value") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if mock.createOpts.Workspace != nil {
+		t.Error("expected CreateRun not to be called")
+	}
+}
+
+func TestRunCreate_MutuallyExclusiveFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"-w", "my-ws", "--refresh-only", "--destroy"},
+		{"-w", "my-ws", "--plan-only", "--auto-apply"},
+	} {
+		viper.Reset()
+		viper.Set("org", "test-org")
+
+		cmd := newCmdRunCreateWith(func() (runCreateService, error) {
+			return newTestRunCreateMock(), nil
+		})
+		cmd.SetArgs(args)
+		cmd.SetErr(&bytes.Buffer{})
+
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("expected error for %v", args)
+		}
+	}
+}
+
+func TestRunCreate_NoWorkspace(t *testing.T) {
+	viper.Reset()
+	viper.Set("org", "test-org")
+
+	cmd := newCmdRunCreateWith(func() (runCreateService, error) {
+		return newTestRunCreateMock(), nil
+	})
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error when workspace is missing")
+	}
+	if !strings.Contains(err.Error(), "workspace is required") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunCreate_NoOrg(t *testing.T) {
+	viper.Reset()
+
+	cmd := newCmdRunCreateWith(func() (runCreateService, error) {
+		return newTestRunCreateMock(), nil
+	})
+	cmd.SetArgs([]string{"-w", "my-ws"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error when org is missing")
+	}
+	if !strings.Contains(err.Error(), "organization is required") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunCreate_CreateError(t *testing.T) {
+	viper.Reset()
+
+	mock := newTestRunCreateMock()
+	mock.createErr = fmt.Errorf("workspace locked")
+
+	err := runRunCreate(mock, "test-org", "my-ws", runCreateOptions{}, time.Millisecond)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "failed to create run") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunCreate_Watch(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", false)
+
+	mock := newTestRunCreateMock()
+	mock.runs = []*tfe.Run{
+		{ID: "run-new123", Status: tfe.RunPending, CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{ID: "run-new123", Status: tfe.RunPlanning, CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{ID: "run-new123", Status: tfe.RunPlannedAndFinished, CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runRunCreate(mock, "test-org", "my-ws", runCreateOptions{Watch: true}, 10*time.Millisecond)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	got := buf.String()
+
+	for _, want := range []string{"run-new123", "Status: planning", "Status: planned_and_finished"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunVariableValue(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"us-east-1", `"us-east-1"`},
+		{"3", "3"},
+		{"-1.5", "-1.5"},
+		{"0", "0"},
+		{"0.25", "0.25"},
+		{"012345678901", `"012345678901"`},
+		{"-007", `"-007"`},
+		{"+1", `"+1"`},
+		{"true", "true"},
+		{`["a","b"]`, `["a","b"]`},
+		{`{a = 1}`, `{a = 1}`},
+		{`"quoted"`, `"quoted"`},
+		{"Inf", `"Inf"`},
+		{"${var}", `"$${var}"`},
+		{"", `""`},
+	}
+	for _, tt := range tests {
+		if got := runVariableValue(tt.input); got != tt.want {
+			t.Errorf("runVariableValue(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/diff"
+	"github.com/nnstt1/hcpt/internal/output"
+)
+
+// Kinds of difference between the planned changes of two runs.
+const (
+	planDiffAdded   = "added"
+	planDiffRemoved = "removed"
+	planDiffChanged = "changed"
+)
+
+type runDiffJSON struct {
+	RunA      string             `json:"run_a"`
+	RunB      string             `json:"run_b"`
+	Resources []resourceDiffJSON `json:"resources"`
+}
+
+// resourceDiffJSON describes how the planned change of a resource differs
+// between run A and run B. Changes compares the planned after-values.
+type resourceDiffJSON struct {
+	Address  string            `json:"address"`
+	Diff     string            `json:"diff"`
+	ActionsA []string          `json:"actions_a"`
+	ActionsB []string          `json:"actions_b"`
+	Changes  map[string]change `json:"changes,omitempty"`
+}
+
+// runDiffService combines RunService, WorkspaceService, and PlanService for plan comparison.
+type runDiffService interface {
+	client.RunService
+	client.WorkspaceService
+	client.PlanService
+}
+
+type runDiffClientFactory func() (runDiffService, error)
+
+func defaultRunDiffClientFactory() (runDiffService, error) {
+	return client.NewClientWrapper()
+}
+
+func newCmdRunDiff() *cobra.Command {
+	return newCmdRunDiffWith(defaultRunDiffClientFactory)
+}
+
+func newCmdRunDiffWith(clientFn runDiffClientFactory) *cobra.Command {
+	var workspaceName string
+	var last int
+
+	cmd := &cobra.Command{
+		Use:   "diff [run-a run-b]",
+		Short: "Compare the plans of two runs",
+		Long: `Compare the planned changes of two runs.
+
+Reports resources that appear only in one of the plans, resources whose
+planned actions differ, and attribute-level differences in the planned
+after-values. With --workspace, the latest run is compared with the run
+--last runs back (default: the previous run).`,
+		Args:         cobra.RangeArgs(0, 2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				return fmt.Errorf("two run IDs are required")
+			}
+			if len(args) == 2 && workspaceName != "" {
+				return fmt.Errorf("cannot specify both run IDs and --workspace/-w")
+			}
+			if len(args) == 0 && workspaceName == "" {
+				return fmt.Errorf("either two run IDs or --workspace/-w is required")
+			}
+			if last < 2 {
+				return fmt.Errorf("--last must be 2 or greater")
+			}
+			// The runs are read in a single page, which holds at most 100 runs.
+			if last > 100 {
+				return fmt.Errorf("--last must be 100 or less")
+			}
+
+			org := viper.GetString("org")
+			if workspaceName != "" && org == "" {
+				return errOrgRequired
+			}
+
+			svc, err := clientFn()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			runA, runB := "", ""
+			if len(args) == 2 {
+				runA, runB = args[0], args[1]
+			} else {
+				runA, runB, err = recentRunIDs(ctx, svc, org, workspaceName, last)
+				if err != nil {
+					return err
+				}
+			}
+			return runRunDiff(ctx, svc, runA, runB)
+		},
+	}
+
+	cmd.Flags().StringVarP(&workspaceName, "workspace", "w", "", "workspace name (compare recent runs)")
+	cmd.Flags().IntVar(&last, "last", 2, "with --workspace, compare the latest run with the Nth most recent run (2-100)")
+
+	return cmd
+}
+
+// recentRunIDs returns the IDs of the Nth most recent run and the latest run
+// of a workspace.
+func recentRunIDs(ctx context.Context, svc runDiffService, org, workspaceName string, last int) (string, string, error) {
+	ws, err := svc.ReadWorkspace(ctx, org, workspaceName)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read workspace %q: %w", workspaceName, err)
+	}
+
+	runList, err := svc.ListRuns(ctx, ws.ID, &tfe.RunListOptions{
+		ListOptions: tfe.ListOptions{PageSize: last},
+		Operation:   "plan_and_apply,plan_only,refresh_only,destroy,empty_apply,save_plan",
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list runs: %w", err)
+	}
+	if len(runList.Items) < last {
+		return "", "", fmt.Errorf("workspace %q has %d run(s), need at least %d", workspaceName, len(runList.Items), last)
+	}
+	return runList.Items[last-1].ID, runList.Items[0].ID, nil
+}
+
+func runRunDiff(ctx context.Context, svc runDiffService, runA, runB string) error {
+	planA, err := readRunPlan(ctx, svc, runA)
+	if err != nil {
+		return err
+	}
+	planB, err := readRunPlan(ctx, svc, runB)
+	if err != nil {
+		return err
+	}
+
+	resources := comparePlans(planA, planB)
+
+	if viper.GetBool("json") {
+		if resources == nil {
+			resources = []resourceDiffJSON{}
+		}
+		return output.PrintJSON(os.Stdout, runDiffJSON{RunA: runA, RunB: runB, Resources: resources})
+	}
+
+	if len(resources) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No differences between the plans of %s and %s\n", runA, runB)
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Comparing %s (A) with %s (B)\n\n", runA, runB)
+	headers := []string{"RESOURCE", "DIFF", "ACTIONS (A)", "ACTIONS (B)"}
+	rows := make([][]string, 0, len(resources))
+	for _, rd := range resources {
+		rows = append(rows, []string{rd.Address, rd.Diff, formatActions(rd.ActionsA), formatActions(rd.ActionsB)})
+	}
+	output.Print(os.Stdout, headers, rows)
+
+	for _, rd := range resources {
+		if len(rd.Changes) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "\nResource: %s\n", rd.Address)
+
+		keys := make([]string, 0, len(rd.Changes))
+		maxKeyLen := 0
+		for k := range rd.Changes {
+			keys = append(keys, k)
+			maxKeyLen = max(maxKeyLen, len(k))
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			a, b := formatChange(rd.Changes[k])
+			padding := strings.Repeat(" ", maxKeyLen-len(k))
+			_, _ = fmt.Fprintf(os.Stdout, "  %s:%s  %s => %s\n", k, padding, a, b)
+		}
+	}
+
+	return nil
+}
+
+// readRunPlan reads a run and parses the JSON output of its plan.
+func readRunPlan(ctx context.Context, svc runDiffService, runID string) (*planDocument, error) {
+	r, err := svc.ReadRun(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run %q: %w", runID, err)
+	}
+	if r.Plan == nil {
+		return nil, fmt.Errorf("run %q does not have a plan", runID)
+	}
+
+	planJSONBytes, err := svc.ReadPlanJSONOutput(ctx, r.Plan.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan JSON of run %q: %w", runID, err)
+	}
+
+	var plan planDocument
+	if err := json.Unmarshal(planJSONBytes, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan JSON of run %q: %w", runID, err)
+	}
+	return &plan, nil
+}
+
+// comparePlans returns the resources whose planned change differs between
+// two plans, sorted by address. No-op changes count as planned actions, so a
+// resource that becomes no-op is reported as changed rather than removed.
+func comparePlans(a, b *planDocument) []resourceDiffJSON {
+	changesA := make(map[string]planResourceChange, len(a.ResourceChanges))
+	for _, rc := range a.ResourceChanges {
+		changesA[rc.Address] = rc
+	}
+	changesB := make(map[string]planResourceChange, len(b.ResourceChanges))
+	for _, rc := range b.ResourceChanges {
+		changesB[rc.Address] = rc
+	}
+
+	addresses := make([]string, 0, len(changesA)+len(changesB))
+	for addr := range changesA {
+		addresses = append(addresses, addr)
+	}
+	for addr := range changesB {
+		if _, ok := changesA[addr]; !ok {
+			addresses = append(addresses, addr)
+		}
+	}
+	sort.Strings(addresses)
+
+	var resources []resourceDiffJSON
+	for _, addr := range addresses {
+		rcA, inA := changesA[addr]
+		rcB, inB := changesB[addr]
+
+		switch {
+		case !inA:
+			resources = append(resources, resourceDiffJSON{Address: addr, Diff: planDiffAdded, ActionsB: rcB.Change.Actions})
+		case !inB:
+			resources = append(resources, resourceDiffJSON{Address: addr, Diff: planDiffRemoved, ActionsA: rcA.Change.Actions})
+		default:
+			afterA, _ := rcA.Change.After.(map[string]interface{})
+			afterB, _ := rcB.Change.After.(map[string]interface{})
+			unknownB, _ := rcB.Change.AfterUnknown.(map[string]interface{})
+			flatUnknownA := make(map[string]interface{})
+			if unknownA, ok := rcA.Change.AfterUnknown.(map[string]interface{}); ok {
+				diff.Flatten("", unknownA, flatUnknownA)
+			}
+
+			var changes map[string]change
+			for _, d := range diff.Compute(afterA, afterB, unknownB, rcA.Change.AfterSensitive, rcB.Change.AfterSensitive) {
+				// An attribute unknown in both plans is computed in both and
+				// is not a difference.
+				unknownInA := diff.IsMarked(d.Key, flatUnknownA)
+				if unknownInA && d.KnownAfterApply {
+					continue
+				}
+				if changes == nil {
+					changes = make(map[string]change)
+				}
+				ch := change{KnownAfterApply: d.KnownAfterApply, BeforeKnownAfterApply: unknownInA, Sensitive: d.Sensitive}
+				if !d.Sensitive {
+					ch.Before = d.BeforeRaw
+					ch.After = d.AfterRaw
+				}
+				if unknownInA {
+					ch.Before = nil
+				}
+				changes[d.Key] = ch
+			}
+
+			if len(changes) == 0 && strings.Join(rcA.Change.Actions, ",") == strings.Join(rcB.Change.Actions, ",") {
+				continue
+			}
+			resources = append(resources, resourceDiffJSON{
+				Address:  addr,
+				Diff:     planDiffChanged,
+				ActionsA: rcA.Change.Actions,
+				ActionsB: rcB.Change.Actions,
+				Changes:  changes,
+			})
+		}
+	}
+	return resources
+}
+
+// formatActions joins planned actions for display, or "-" if there are none.
+func formatActions(actions []string) string {
+	if len(actions) == 0 {
+		return "-"
+	}
+	return strings.Join(actions, ", ")
+}
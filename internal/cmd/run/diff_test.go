@@ -0,0 +1,358 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/viper"
+)
+
+type mockRunDiffService struct {
+	runList   *tfe.RunList
+	planJSONs map[string]string // plan ID -> plan JSON
+	listOpts  *tfe.RunListOptions
+}
+
+func (m *mockRunDiffService) ListRuns(_ context.Context, _ string, opts *tfe.RunListOptions) (*tfe.RunList, error) {
+	m.listOpts = opts
+	return m.runList, nil
+}
+
+func (m *mockRunDiffService) ReadRun(_ context.Context, runID string) (*tfe.Run, error) {
+	planID := strings.Replace(runID, "run-", "plan-", 1)
+	if _, ok := m.planJSONs[planID]; !ok {
+		return nil, fmt.Errorf("run %q not found", runID)
+	}
+	return &tfe.Run{ID: runID, Plan: &tfe.Plan{ID: planID}}, nil
+}
+
+func (m *mockRunDiffService) ReadRunWithApply(ctx context.Context, runID string) (*tfe.Run, error) {
+	return m.ReadRun(ctx, runID)
+}
+
+func (m *mockRunDiffService) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, fmt.Errorf("CreateRun not implemented in mockRunDiffService")
+}
+
+func (m *mockRunDiffService) ListWorkspaces(_ context.Context, _ string, _ *tfe.WorkspaceListOptions) (*tfe.WorkspaceList, error) {
+	return nil, nil
+}
+
+func (m *mockRunDiffService) ReadWorkspace(_ context.Context, _, name string) (*tfe.Workspace, error) {
+	return &tfe.Workspace{ID: "ws-abc123", Name: name}, nil
+}
+
+func (m *mockRunDiffService) ReadPlanJSONOutput(_ context.Context, planID string) ([]byte, error) {
+	return []byte(m.planJSONs[planID]), nil
+}
+
+func (m *mockRunDiffService) ReadPlanLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, fmt.Errorf("ReadPlanLogs not implemented in mockRunDiffService")
+}
+
+const diffPlanA = `{
+  "resource_changes": [
+    {"address": "aws_instance.web", "change": {"actions": ["update"], "after": {"instance_type": "t3.micro", "ami": "ami-1"}}},
+    {"address": "aws_s3_bucket.logs", "change": {"actions": ["create"], "after": {"bucket": "logs"}}},
+    {"address": "aws_iam_role.app", "change": {"actions": ["no-op"], "after": {"name": "app"}}},
+    {"address": "aws_db_instance.main", "change": {"actions": ["update"], "after": {"password": "old-secret"}, "after_sensitive": {"password": true}}}
+  ]
+}`
+
+const diffPlanB = `{
+  "resource_changes": [
+    {"address": "aws_instance.web", "change": {"actions": ["update"], "after": {"instance_type": "t3.small", "ami": "ami-1"}}},
+    {"address": "aws_iam_role.app", "change": {"actions": ["no-op"], "after": {"name": "app"}}},
+    {"address": "aws_db_instance.main", "change": {"actions": ["delete", "create"], "after": {"password": "new-secret"}, "after_sensitive": {"password": true}}},
+    {"address": "aws_sqs_queue.jobs", "change": {"actions": ["create"], "after": {"name": "jobs"}, "after_unknown": {"arn": true}}}
+  ]
+}`
+
+func newTestRunDiffMock() *mockRunDiffService {
+	return &mockRunDiffService{
+		planJSONs: map[string]string{
+			"plan-a": diffPlanA,
+			"plan-b": diffPlanB,
+		},
+	}
+}
+
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := fn()
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+func TestComparePlans(t *testing.T) {
+	var a, b planDocument
+	if err := json.Unmarshal([]byte(diffPlanA), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(diffPlanB), &b); err != nil {
+		t.Fatal(err)
+	}
+
+	resources := comparePlans(&a, &b)
+
+	got := make([]string, 0, len(resources))
+	for _, rd := range resources {
+		got = append(got, rd.Address+":"+rd.Diff)
+	}
+	want := "aws_db_instance.main:changed,aws_instance.web:changed,aws_s3_bucket.logs:removed,aws_sqs_queue.jobs:added"
+	if strings.Join(got, ",") != want {
+		t.Errorf("unexpected resources:\ngot:  %s\nwant: %s", strings.Join(got, ","), want)
+	}
+
+	web := resources[1]
+	if len(web.Changes) != 1 || web.Changes["instance_type"].Before != "t3.micro" || web.Changes["instance_type"].After != "t3.small" {
+		t.Errorf("unexpected web changes: %+v", web.Changes)
+	}
+
+	db := resources[0]
+	if strings.Join(db.ActionsB, ",") != "delete,create" {
+		t.Errorf("unexpected db actions: %v", db.ActionsB)
+	}
+	if pw := db.Changes["password"]; !pw.Sensitive || pw.Before != nil || pw.After != nil {
+		t.Errorf("expected masked password change, got %+v", pw)
+	}
+}
+
+func TestComparePlans_KnownAfterApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		changeA   string
+		changeB   string
+		wantDiff  bool
+		wantLine  string
+		wantAfter interface{}
+	}{
+		{
+			name:    "unknown in both",
+			changeA: `{"actions": ["update"], "after": {"arn": null, "name": "x"}, "after_unknown": {"arn": true}}`,
+			changeB: `{"actions": ["update"], "after": {"name": "x"}, "after_unknown": {"arn": true}}`,
+		},
+		{
+			name:      "unknown in A only",
+			changeA:   `{"actions": ["update"], "after": {"name": "x"}, "after_unknown": {"arn": true}}`,
+			changeB:   `{"actions": ["update"], "after": {"arn": "arn:aws:1", "name": "x"}}`,
+			wantDiff:  true,
+			wantLine:  "(known after apply) => arn:aws:1",
+			wantAfter: "arn:aws:1",
+		},
+		{
+			name:     "unknown in B only",
+			changeA:  `{"actions": ["update"], "after": {"arn": "arn:aws:1", "name": "x"}}`,
+			changeB:  `{"actions": ["update"], "after": {"name": "x"}, "after_unknown": {"arn": true}}`,
+			wantDiff: true,
+			wantLine: "arn:aws:1 => (known after apply)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a, b planDocument
+			if err := json.Unmarshal([]byte(`{"resource_changes": [{"address": "aws_sqs_queue.jobs", "change": `+tt.changeA+`}]}`), &a); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(`{"resource_changes": [{"address": "aws_sqs_queue.jobs", "change": `+tt.changeB+`}]}`), &b); err != nil {
+				t.Fatal(err)
+			}
+
+			resources := comparePlans(&a, &b)
+			if !tt.wantDiff {
+				if len(resources) != 0 {
+					t.Errorf("expected no differences, got %+v", resources)
+				}
+				return
+			}
+			if len(resources) != 1 {
+				t.Fatalf("expected 1 resource, got %+v", resources)
+			}
+			ch, ok := resources[0].Changes["arn"]
+			if !ok {
+				t.Fatalf("expected arn change, got %+v", resources[0].Changes)
+			}
+			if tt.wantAfter != nil && ch.After != tt.wantAfter {
+				t.Errorf("expected after %v, got %v", tt.wantAfter, ch.After)
+			}
+			before, after := formatChange(ch)
+			if got := before + " => " + after; got != tt.wantLine {
+				t.Errorf("expected %q, got %q", tt.wantLine, got)
+			}
+		})
+	}
+}
+
+func TestRunDiff_Table(t *testing.T) {
+	viper.Reset()
+
+	out, err := captureStdout(t, func() error {
+		return runRunDiff(context.Background(), newTestRunDiffMock(), "run-a", "run-b")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Comparing run-a (A) with run-b (B)",
+		"aws_sqs_queue.jobs",
+		"added",
+		"aws_db_instance.main  changed  update       delete, create",
+		"Resource: aws_instance.web",
+		"instance_type:  t3.micro => t3.small",
+		"password:  (sensitive value) => (sensitive value)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret") || strings.Contains(out, "aws_iam_role.app") {
+		t.Errorf("unexpected content in output:\n%s", out)
+	}
+}
+
+func TestRunDiff_JSON(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	out, err := captureStdout(t, func() error {
+		return runRunDiff(context.Background(), newTestRunDiffMock(), "run-a", "run-b")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result runDiffJSON
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v\noutput: %s", err, out)
+	}
+	if result.RunA != "run-a" || result.RunB != "run-b" || len(result.Resources) != 4 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestRunDiff_NoDifferences(t *testing.T) {
+	viper.Reset()
+
+	mock := newTestRunDiffMock()
+	mock.planJSONs["plan-c"] = diffPlanA
+
+	out, err := captureStdout(t, func() error {
+		return runRunDiff(context.Background(), mock, "run-a", "run-c")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "No differences between the plans of run-a and run-c\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestRunDiff_Workspace(t *testing.T) {
+	viper.Reset()
+	viper.Set("org", "test-org")
+	viper.Set("json", true)
+
+	mock := newTestRunDiffMock()
+	mock.runList = &tfe.RunList{Items: []*tfe.Run{{ID: "run-b"}, {ID: "run-x"}, {ID: "run-a"}}}
+
+	cmd := newCmdRunDiffWith(func() (runDiffService, error) {
+		return mock, nil
+	})
+	cmd.SetArgs([]string{"-w", "my-ws", "--last", "3"})
+
+	out, err := captureStdout(t, cmd.Execute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.listOpts == nil || mock.listOpts.PageSize != 3 {
+		t.Errorf("expected page size 3, got %+v", mock.listOpts)
+	}
+
+	var result runDiffJSON
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v\noutput: %s", err, out)
+	}
+	if result.RunA != "run-a" || result.RunB != "run-b" {
+		t.Errorf("expected run-a vs run-b, got %s vs %s", result.RunA, result.RunB)
+	}
+}
+
+func TestRunDiff_NotEnoughRuns(t *testing.T) {
+	viper.Reset()
+	viper.Set("org", "test-org")
+
+	mock := newTestRunDiffMock()
+	mock.runList = &tfe.RunList{Items: []*tfe.Run{{ID: "run-b"}}}
+
+	cmd := newCmdRunDiffWith(func() (runDiffService, error) {
+		return mock, nil
+	})
+	cmd.SetArgs([]string{"-w", "my-ws"})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "need at least 2") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunDiff_ArgValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		org  string
+		want string
+	}{
+		{"no args", []string{}, "test-org", "either two run IDs or --workspace/-w is required"},
+		{"one run", []string{"run-a"}, "test-org", "two run IDs are required"},
+		{"runs and workspace", []string{"run-a", "run-b", "-w", "my-ws"}, "test-org", "cannot specify both run IDs and --workspace/-w"},
+		{"last too small", []string{"-w", "my-ws", "--last", "1"}, "test-org", "--last must be 2 or greater"},
+		{"last too large", []string{"-w", "my-ws", "--last", "150"}, "test-org", "--last must be 100 or less"},
+		{"workspace without org", []string{"-w", "my-ws"}, "", "organization is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			if tt.org != "" {
+				viper.Set("org", tt.org)
+			}
+
+			cmd := newCmdRunDiffWith(func() (runDiffService, error) {
+				return newTestRunDiffMock(), nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package run
+
+import (
+	"fmt"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// Exit codes of watched runs. Other errors exit with 1.
+const (
+	exitCodeErrored           = 2
+	exitCodeCanceled          = 3
+	exitCodeDiscarded         = 4
+	exitCodePolicySoftFailed  = 5
+	exitCodeNeedsConfirmation = 6
+	exitCodeTimeout           = 7
+	exitCodeInterrupted       = 130
+)
+
+// ExitError is an error that carries the exit code of the command.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// watchExitError returns the error for the status a watched run stopped in,
+// or nil if the run succeeded.
+func watchExitError(r *tfe.Run) error {
+	code := 0
+	switch r.Status {
+	case tfe.RunApplied, tfe.RunPlannedAndFinished, tfe.RunPlannedAndSaved:
+		return nil
+	case tfe.RunErrored:
+		code = exitCodeErrored
+	case tfe.RunCanceled:
+		code = exitCodeCanceled
+	case tfe.RunDiscarded:
+		code = exitCodeDiscarded
+	case tfe.RunPolicySoftFailed:
+		return &ExitError{Code: exitCodePolicySoftFailed, Err: fmt.Errorf("run %s requires a policy override", r.ID)}
+	default:
+		return &ExitError{Code: exitCodeNeedsConfirmation, Err: fmt.Errorf("run %s is awaiting confirmation (status: %s)", r.ID, r.Status)}
+	}
+	return &ExitError{Code: code, Err: fmt.Errorf("run %s finished with status %s", r.ID, r.Status)}
+}
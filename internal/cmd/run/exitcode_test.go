@@ -0,0 +1,58 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+func TestWatchExitError(t *testing.T) {
+	tests := []struct {
+		status tfe.RunStatus
+		want   int
+	}{
+		{tfe.RunApplied, 0},
+		{tfe.RunPlannedAndFinished, 0},
+		{tfe.RunPlannedAndSaved, 0},
+		{tfe.RunErrored, exitCodeErrored},
+		{tfe.RunCanceled, exitCodeCanceled},
+		{tfe.RunDiscarded, exitCodeDiscarded},
+		{tfe.RunPolicySoftFailed, exitCodePolicySoftFailed},
+		{tfe.RunPlanned, exitCodeNeedsConfirmation},
+		{tfe.RunCostEstimated, exitCodeNeedsConfirmation},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			err := watchExitError(&tfe.Run{ID: "run-abc123", Status: tt.status})
+			if tt.want == 0 {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("expected *ExitError, got %v", err)
+			}
+			if exitErr.Code != tt.want {
+				t.Errorf("expected exit code %d, got %d", tt.want, exitErr.Code)
+			}
+		})
+	}
+}
+
+func TestExitError_Unwrap(t *testing.T) {
+	inner := fmt.Errorf("inner")
+	err := fmt.Errorf("wrapped: %w", &ExitError{Code: exitCodeTimeout, Err: inner})
+
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitCodeTimeout {
+		t.Fatalf("expected exit code %d, got %v", exitCodeTimeout, err)
+	}
+	if !errors.Is(err, inner) {
+		t.Error("expected ExitError to unwrap to the inner error")
+	}
+}
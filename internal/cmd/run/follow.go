@@ -0,0 +1,176 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// followDone is the stop condition of --follow: like run show --watch with
+// --stop-on-confirmation, a run awaiting confirmation or a policy override
+// has no more logs to follow until a user acts on it.
+var followDone = runShowOptions{StopOnConfirmation: true}
+
+// logStream tracks the read position in one phase's log.
+type logStream struct {
+	offset  int64
+	started bool   // the STX marker has been consumed
+	partial []byte // trailing bytes without a newline yet
+}
+
+// followRunLogs tails the plan and apply logs of a run until the run reaches a
+// terminal status or awaits a user, printing only new lines. It returns the
+// ExitError of run show --watch if the run did not finish successfully, or an
+// interrupted ExitError if ctx is canceled.
+func followRunLogs(ctx context.Context, svc runLogsService, runID string, opts runLogsOptions, pollInterval time.Duration) error {
+	w := os.Stdout
+
+	phases := []string{logPhasePlan, logPhaseApply}
+	switch opts.Phase {
+	case logPhasePlan:
+		phases = phases[:1]
+	case logPhaseApply:
+		phases = phases[1:]
+	}
+	showHeaders := len(phases) > 1
+
+	current := 0
+	stream := &logStream{}
+	headerPrinted := false
+	var status tfe.RunStatus
+
+	for {
+		r, err := svc.ReadRunWithApply(ctx, runID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return watchStopped(ctx, runID, status, 0)
+			}
+			// Possibly a transient error; print warning and continue
+			fmt.Fprintf(os.Stderr, "Warning: failed to read run: %v\n", err)
+		} else {
+			status = r.Status
+			phase := phases[current]
+			logURL, started, finished := phaseLogState(r, phase)
+
+			if started && logURL != "" {
+				if showHeaders && !headerPrinted {
+					printPhaseHeader(w, phase)
+					headerPrinted = true
+				}
+				if err := drainLog(ctx, svc, logURL, stream, w, opts); err != nil {
+					if ctx.Err() != nil {
+						return watchStopped(ctx, runID, status, 0)
+					}
+					fmt.Fprintf(os.Stderr, "Warning: failed to read %s logs: %v\n", phase, err)
+				}
+			}
+
+			done := isWatchDone(r, followDone)
+			if finished || done {
+				flushPartial(stream, w, opts)
+
+				// Move on to the apply unless the run ended or stopped
+				// without one
+				applySkipped := done && !hasApplyStarted(r)
+				if current+1 < len(phases) && !applySkipped {
+					current++
+					stream = &logStream{}
+					headerPrinted = false
+					continue
+				}
+				if isTerminalStatus(r.Status) || (done && !(finished && len(phases) == 1)) {
+					return watchExitError(r)
+				}
+				// Only the requested phase was followed and it has finished
+				if (phase == logPhasePlan && r.Plan.Status == tfe.PlanErrored) ||
+					(phase == logPhaseApply && r.Apply.Status == tfe.ApplyErrored) {
+					return &ExitError{Code: exitCodeErrored, Err: fmt.Errorf("run %s: %s errored", r.ID, phase)}
+				}
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return watchStopped(ctx, runID, status, 0)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// phaseLogState returns the log URL of a phase and whether the phase has
+// started and finished producing logs.
+func phaseLogState(r *tfe.Run, phase string) (logURL string, started, finished bool) {
+	if phase == logPhasePlan {
+		if r.Plan == nil {
+			return "", false, false
+		}
+		switch r.Plan.Status {
+		case tfe.PlanPending, tfe.PlanQueued, tfe.PlanCreated:
+			return r.Plan.LogReadURL, false, false
+		case tfe.PlanFinished, tfe.PlanErrored, tfe.PlanCanceled, tfe.PlanUnreachable:
+			return r.Plan.LogReadURL, true, true
+		default:
+			return r.Plan.LogReadURL, true, false
+		}
+	}
+
+	if !hasApplyStarted(r) {
+		return "", false, false
+	}
+	switch r.Apply.Status {
+	case tfe.ApplyQueued, tfe.ApplyCreated:
+		return r.Apply.LogReadURL, false, false
+	case tfe.ApplyFinished, tfe.ApplyErrored, tfe.ApplyCanceled:
+		return r.Apply.LogReadURL, true, true
+	default:
+		return r.Apply.LogReadURL, true, false
+	}
+}
+
+// drainLog reads all log output available after the stream's offset and
+// prints complete lines.
+func drainLog(ctx context.Context, svc runLogsService, logURL string, stream *logStream, w io.Writer, opts runLogsOptions) error {
+	for {
+		chunk, err := svc.ReadLogChunk(ctx, logURL, stream.offset)
+		if err != nil {
+			return err
+		}
+		if len(chunk) == 0 {
+			return nil
+		}
+		stream.offset += int64(len(chunk))
+
+		// Strip the STX/ETX markers that delimit the log stream
+		if !stream.started && chunk[0] == 0x02 {
+			chunk = chunk[1:]
+		}
+		stream.started = true
+		chunk = bytes.TrimSuffix(chunk, []byte{0x03})
+
+		data := append(stream.partial, chunk...)
+		lastNewline := bytes.LastIndexByte(data, '\n')
+		if lastNewline < 0 {
+			stream.partial = data
+			continue
+		}
+		if err := printLogs(w, bytes.NewReader(data[:lastNewline+1]), opts); err != nil {
+			return err
+		}
+		stream.partial = append([]byte(nil), data[lastNewline+1:]...)
+	}
+}
+
+// flushPartial prints any buffered output that did not end with a newline.
+func flushPartial(stream *logStream, w io.Writer, opts runLogsOptions) {
+	if len(stream.partial) == 0 {
+		return
+	}
+	_ = printLogs(w, bytes.NewReader(stream.partial), opts)
+	stream.partial = nil
+}
@@ -0,0 +1,17 @@
+package run
+
+import (
+	"context"
+
+	"github.com/nnstt1/hcpt/internal/client"
+)
+
+// resolveRunIDFromMR fetches the HCP Terraform run-id from a GitLab merge request's commit statuses.
+func resolveRunIDFromMR(ctx context.Context, project string, mrIID int, workspaceName string) (string, error) {
+	glClient, err := client.NewGitLabClientWrapper()
+	if err != nil {
+		return "", err
+	}
+
+	return glClient.GetRunIDFromMR(ctx, project, mrIID, workspaceName)
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	tfe "github.com/hashicorp/go-tfe"
@@ -15,19 +19,45 @@ import (
 	"github.com/nnstt1/hcpt/internal/output"
 )
 
+// maxConcurrentWorkspaces bounds the number of workspaces whose runs are
+// listed in parallel with --all-workspaces.
+const maxConcurrentWorkspaces = 8
+
 type runJSON struct {
 	ID         string    `json:"id"`
+	Workspace  string    `json:"workspace,omitempty"`
 	Status     string    `json:"status"`
 	Message    string    `json:"message"`
 	PlanOnly   bool      `json:"plan_only"`
 	HasChanges bool      `json:"has_changes"`
 	CreatedAt  time.Time `json:"created_at"`
+	CostDelta  *string   `json:"delta_monthly_cost,omitempty"`
+}
+
+// runListOptions holds the filters and display options of run list.
+type runListOptions struct {
+	Status   string
+	WithCost bool
+	// Since excludes runs created before it when non-zero.
+	Since time.Time
+	// AllWorkspaces lists runs across every workspace in the organization
+	// matching Projects and Tags.
+	AllWorkspaces bool
+	Projects      []string
+	Tags          []string
 }
 
-// runListService combines RunService and WorkspaceService for workspace ID resolution.
+// workspaceRun is a run together with the name of its workspace.
+type workspaceRun struct {
+	Workspace string
+	Run       *tfe.Run
+}
+
+// runListService combines RunService, WorkspaceService, and ExplorerService for run listing.
 type runListService interface {
 	client.RunService
 	client.WorkspaceService
+	client.ExplorerService
 }
 
 type runListClientFactory func() (runListService, error)
@@ -42,98 +72,290 @@ func newCmdRunList() *cobra.Command {
 
 func newCmdRunListWith(clientFn runListClientFactory) *cobra.Command {
 	var workspaceName string
-	var status string
+	var since string
+	var opts runListOptions
 
 	cmd := &cobra.Command{
-		Use:          "list",
-		Short:        "List runs for a workspace",
+		Use:   "list",
+		Short: "List runs for a workspace or across an organization",
+		Long: `List runs for a workspace, or with --all-workspaces, for every workspace in
+the organization. Runs across workspaces are sorted by creation time, newest
+first, and can be narrowed down with --project and --tag. --all-workspaces
+requires --since, so that the full run history of every workspace is not
+paged through.`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			org := viper.GetString("org")
 			if org == "" {
 				return errOrgRequired
 			}
-			if workspaceName == "" {
-				return errWorkspaceRequired
+			if opts.AllWorkspaces && workspaceName != "" {
+				return fmt.Errorf("cannot specify both --workspace/-w and --all-workspaces")
+			}
+			if opts.AllWorkspaces && since == "" {
+				return fmt.Errorf("--all-workspaces requires --since to bound the runs listed (e.g. --since 1d)")
+			}
+			if !opts.AllWorkspaces {
+				if workspaceName == "" {
+					return errWorkspaceRequired
+				}
+				if len(opts.Projects) > 0 || len(opts.Tags) > 0 {
+					return fmt.Errorf("--project and --tag require --all-workspaces")
+				}
+			}
+			if since != "" {
+				t, err := parseSince(since, time.Now())
+				if err != nil {
+					return err
+				}
+				opts.Since = t
 			}
 
 			svc, err := clientFn()
 			if err != nil {
 				return err
 			}
-			return runRunList(svc, org, workspaceName, status)
+			return runRunList(svc, org, workspaceName, opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&workspaceName, "workspace", "w", "", "workspace name (required)")
-	cmd.Flags().StringVar(&status, "status", "", "filter by run status (comma-separated, e.g. applied,errored)")
+	cmd.Flags().StringVarP(&workspaceName, "workspace", "w", "", "workspace name (required unless --all-workspaces)")
+	cmd.Flags().StringVar(&opts.Status, "status", "", "filter by run status (comma-separated, e.g. applied,errored)")
+	cmd.Flags().BoolVar(&opts.WithCost, "with-cost", false, "show the monthly cost delta of each run's cost estimate")
+	cmd.Flags().BoolVar(&opts.AllWorkspaces, "all-workspaces", false, "list runs across all workspaces in the organization (requires --since)")
+	cmd.Flags().StringVar(&since, "since", "", "show runs created within a duration (e.g. 1h, 30d) or after an RFC 3339 time")
+	cmd.Flags().StringArrayVar(&opts.Projects, "project", nil, "with --all-workspaces, filter by project name (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Tags, "tag", nil, "with --all-workspaces, filter by workspace tag as \"key\" or \"key:value\" (can be repeated)")
 
 	return cmd
 }
 
-func runRunList(svc runListService, org, workspaceName, status string) error {
-	ctx := context.Background()
-
-	// Resolve workspace name to ID
-	ws, err := svc.ReadWorkspace(ctx, org, workspaceName)
+// parseSince parses a --since value, either a duration before now (e.g. 1h,
+// or 30d in days) or an RFC 3339 timestamp.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, ok := parseDays(s); ok {
+		return now.AddDate(0, 0, -d), nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid --since %q: duration must be positive", s)
+		}
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
 	if err != nil {
-		return fmt.Errorf("failed to read workspace %q: %w", workspaceName, err)
+		return time.Time{}, fmt.Errorf("invalid --since %q: must be a duration (e.g. 1h, 30d) or an RFC 3339 time", s)
 	}
+	return t, nil
+}
 
-	opts := &tfe.RunListOptions{
-		ListOptions: tfe.ListOptions{
-			PageSize: 100,
-		},
-		// plan_only is excluded by default, so explicitly specify all operation types
-		Operation: "plan_and_apply,plan_only,refresh_only,destroy,empty_apply,save_plan",
-		Status:    status,
+// parseDays parses a positive number of days such as "30d".
+func parseDays(s string) (int, bool) {
+	n, found := strings.CutSuffix(s, "d")
+	if !found {
+		return 0, false
+	}
+	d, err := strconv.Atoi(n)
+	if err != nil || d <= 0 {
+		return 0, false
 	}
+	return d, true
+}
 
-	var allItems []*tfe.Run
-	for {
-		runList, err := svc.ListRuns(ctx, ws.ID, opts)
-		if err != nil {
-			return fmt.Errorf("failed to list runs: %w", err)
-		}
-		allItems = append(allItems, runList.Items...)
-		if runList.Pagination == nil || runList.NextPage == 0 {
-			break
-		}
-		opts.PageNumber = runList.NextPage
+func runRunList(svc runListService, org, workspaceName string, opts runListOptions) error {
+	runs, err := collectRuns(context.Background(), svc, org, workspaceName, opts)
+	if err != nil {
+		return err
 	}
 
 	if viper.GetBool("json") {
-		items := make([]runJSON, 0, len(allItems))
-		for _, r := range allItems {
-			items = append(items, runJSON{
+		items := make([]runJSON, 0, len(runs))
+		for _, wr := range runs {
+			r := wr.Run
+			item := runJSON{
 				ID:         r.ID,
+				Workspace:  wr.Workspace,
 				Status:     string(r.Status),
 				Message:    r.Message,
 				PlanOnly:   r.PlanOnly,
 				HasChanges: r.HasChanges,
 				CreatedAt:  r.CreatedAt,
-			})
+			}
+			if opts.WithCost && hasCostEstimate(r) && r.CostEstimate.Status == tfe.CostEstimateFinished {
+				item.CostDelta = &r.CostEstimate.DeltaMonthlyCost
+			}
+			items = append(items, item)
 		}
 		return output.PrintJSON(os.Stdout, items)
 	}
 
 	headers := []string{"ID", "STATUS", "MESSAGE", "PLAN ONLY", "HAS CHANGES", "CREATED AT"}
-	rows := make([][]string, 0, len(allItems))
-	for _, r := range allItems {
-		rows = append(rows, []string{
+	if opts.AllWorkspaces {
+		headers = append([]string{"WORKSPACE"}, headers...)
+	}
+	if opts.WithCost {
+		headers = append(headers, "COST DELTA")
+	}
+	rows := make([][]string, 0, len(runs))
+	for _, wr := range runs {
+		r := wr.Run
+		row := []string{
 			r.ID,
 			string(r.Status),
 			truncate(r.Message, 50),
 			strconv.FormatBool(r.PlanOnly),
 			strconv.FormatBool(r.HasChanges),
 			r.CreatedAt.Format("2006-01-02 15:04:05"),
-		})
+		}
+		if opts.AllWorkspaces {
+			row = append([]string{wr.Workspace}, row...)
+		}
+		if opts.WithCost {
+			delta := "-"
+			if hasCostEstimate(r) && r.CostEstimate.Status == tfe.CostEstimateFinished {
+				delta = formatCostDelta(r.CostEstimate.DeltaMonthlyCost)
+			}
+			row = append(row, delta)
+		}
+		rows = append(rows, row)
 	}
 
 	output.Print(os.Stdout, headers, rows)
 	return nil
 }
 
+// collectRuns lists the runs of a workspace, or with opts.AllWorkspaces, of
+// the matching workspaces in the organization. Workspace is only set on runs
+// listed across workspaces.
+func collectRuns(ctx context.Context, svc runListService, org, workspaceName string, opts runListOptions) ([]workspaceRun, error) {
+	if opts.AllWorkspaces {
+		return listOrgRuns(ctx, svc, org, opts)
+	}
+
+	// Resolve workspace name to ID
+	ws, err := svc.ReadWorkspace(ctx, org, workspaceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace %q: %w", workspaceName, err)
+	}
+
+	items, err := listWorkspaceRuns(ctx, svc, ws.ID, opts)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]workspaceRun, 0, len(items))
+	for _, r := range items {
+		runs = append(runs, workspaceRun{Run: r})
+	}
+	return runs, nil
+}
+
+// listWorkspaceRuns lists the runs of a workspace, newest first. With
+// opts.Since, paging stops at the first run created before it.
+func listWorkspaceRuns(ctx context.Context, svc client.RunService, workspaceID string, opts runListOptions) ([]*tfe.Run, error) {
+	listOpts := &tfe.RunListOptions{
+		ListOptions: tfe.ListOptions{
+			PageSize: 100,
+		},
+		// plan_only is excluded by default, so explicitly specify all operation types
+		Operation: "plan_and_apply,plan_only,refresh_only,destroy,empty_apply,save_plan",
+		Status:    opts.Status,
+	}
+	if opts.WithCost {
+		listOpts.Include = []tfe.RunIncludeOpt{tfe.RunCostEstimate}
+	}
+
+	var allItems []*tfe.Run
+	for {
+		runList, err := svc.ListRuns(ctx, workspaceID, listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list runs: %w", err)
+		}
+		for _, r := range runList.Items {
+			if !opts.Since.IsZero() && r.CreatedAt.Before(opts.Since) {
+				return allItems, nil
+			}
+			allItems = append(allItems, r)
+		}
+		if runList.Pagination == nil || runList.NextPage == 0 {
+			break
+		}
+		listOpts.PageNumber = runList.NextPage
+	}
+	return allItems, nil
+}
+
+// listOrgRuns lists the runs of every workspace in the organization that
+// matches the project and tag filters, newest first. Workspaces are found with
+// the Explorer API and their runs are listed concurrently.
+func listOrgRuns(ctx context.Context, svc runListService, org string, opts runListOptions) ([]workspaceRun, error) {
+	var workspaces []client.ExplorerWorkspace
+	page := 1
+	for {
+		result, err := svc.ListExplorerWorkspaces(ctx, org, client.ExplorerListOptions{Page: page})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query explorer: %w", err)
+		}
+		for _, ws := range result.Items {
+			if len(opts.Projects) > 0 && !slices.Contains(opts.Projects, ws.ProjectName) {
+				continue
+			}
+			if len(opts.Tags) > 0 && !slices.ContainsFunc(opts.Tags, ws.HasTag) {
+				continue
+			}
+			workspaces = append(workspaces, ws)
+		}
+		if page >= result.TotalPages {
+			break
+		}
+		page = result.NextPage
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The first error cancels the remaining requests and is the one reported.
+	results := make([][]*tfe.Run, len(workspaces))
+	var firstErr error
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxConcurrentWorkspaces)
+	var wg sync.WaitGroup
+	for i, ws := range workspaces {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+			items, err := listWorkspaceRuns(ctx, svc, ws.WorkspaceID, opts)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("workspace %q: %w", ws.WorkspaceName, err)
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			results[i] = items
+		})
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var runs []workspaceRun
+	for i, ws := range workspaces {
+		for _, r := range results[i] {
+			runs = append(runs, workspaceRun{Workspace: ws.WorkspaceName, Run: r})
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Run.CreatedAt.After(runs[j].Run.CreatedAt)
+	})
+	return runs, nil
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
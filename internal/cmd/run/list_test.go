@@ -3,6 +3,7 @@ package run
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -11,6 +12,8 @@ import (
 
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
 )
 
 type mockRunListService struct {
@@ -21,6 +24,10 @@ type mockRunListService struct {
 	run        *tfe.Run
 	readRunErr error
 	listRunFn  func(opts *tfe.RunListOptions) (*tfe.RunList, error)
+	// explorer and workspaceRuns back --all-workspaces listing.
+	explorer      []client.ExplorerWorkspace
+	workspaceRuns map[string][]*tfe.Run
+	workspaceErr  map[string]error
 }
 
 func (m *mockRunListService) ReadWorkspace(_ context.Context, _ string, _ string) (*tfe.Workspace, error) {
@@ -34,7 +41,13 @@ func (m *mockRunListService) ListWorkspaces(_ context.Context, _ string, _ *tfe.
 	return nil, nil
 }
 
-func (m *mockRunListService) ListRuns(_ context.Context, _ string, opts *tfe.RunListOptions) (*tfe.RunList, error) {
+func (m *mockRunListService) ListRuns(_ context.Context, workspaceID string, opts *tfe.RunListOptions) (*tfe.RunList, error) {
+	if m.workspaceRuns != nil {
+		if err := m.workspaceErr[workspaceID]; err != nil {
+			return nil, err
+		}
+		return &tfe.RunList{Items: m.workspaceRuns[workspaceID]}, nil
+	}
 	if m.listRunFn != nil {
 		return m.listRunFn(opts)
 	}
@@ -46,6 +59,10 @@ func (m *mockRunListService) ListRuns(_ context.Context, _ string, opts *tfe.Run
 	}, nil
 }
 
+func (m *mockRunListService) ListExplorerWorkspaces(_ context.Context, _ string, _ client.ExplorerListOptions) (*client.ExplorerWorkspaceList, error) {
+	return &client.ExplorerWorkspaceList{Items: m.explorer, TotalPages: 1}, nil
+}
+
 func (m *mockRunListService) ReadRun(_ context.Context, _ string) (*tfe.Run, error) {
 	if m.readRunErr != nil {
 		return nil, m.readRunErr
@@ -60,6 +77,10 @@ func (m *mockRunListService) ReadRunWithApply(_ context.Context, _ string) (*tfe
 	return m.run, nil
 }
 
+func (m *mockRunListService) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, fmt.Errorf("CreateRun not implemented in mockRunListService")
+}
+
 func TestRunList_Table(t *testing.T) {
 	viper.Reset()
 	viper.Set("json", false)
@@ -130,7 +151,7 @@ func TestRunList_Table_Output(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunList(mock, "test-org", "my-ws", "")
+	err := runRunList(mock, "test-org", "my-ws", runListOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -172,7 +193,7 @@ func TestRunList_JSON(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunList(mock, "test-org", "my-ws", "")
+	err := runRunList(mock, "test-org", "my-ws", runListOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -229,7 +250,7 @@ func TestRunList_Pagination(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunList(mock, "test-org", "my-ws", "")
+	err := runRunList(mock, "test-org", "my-ws", runListOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -491,3 +512,288 @@ func TestTruncate(t *testing.T) {
 		}
 	}
 }
+
+func TestRunList_WithCost(t *testing.T) {
+	var capturedInclude []tfe.RunIncludeOpt
+	mock := &mockRunListService{
+		workspace: &tfe.Workspace{ID: "ws-abc123", Name: "my-ws"},
+		listRunFn: func(opts *tfe.RunListOptions) (*tfe.RunList, error) {
+			capturedInclude = opts.Include
+			return &tfe.RunList{
+				Items: []*tfe.Run{
+					{
+						ID:        "run-123",
+						Status:    tfe.RunApplied,
+						CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						CostEstimate: &tfe.CostEstimate{
+							Status:           tfe.CostEstimateFinished,
+							DeltaMonthlyCost: "3.5",
+						},
+					},
+					{
+						ID:           "run-456",
+						Status:       tfe.RunErrored,
+						CreatedAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+						CostEstimate: &tfe.CostEstimate{Status: tfe.CostEstimateErrored},
+					},
+				},
+			}, nil
+		},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		viper.Reset()
+		got, err := captureStdout(t, func() error {
+			return runRunList(mock, "test-org", "my-ws", runListOptions{WithCost: true})
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(capturedInclude) != 1 || capturedInclude[0] != tfe.RunCostEstimate {
+			t.Errorf("expected cost_estimate include, got %v", capturedInclude)
+		}
+		for _, want := range []string{"COST DELTA", "+$3.50"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("expected %q in output, got:\n%s", want, got)
+			}
+		}
+		lines := strings.Split(strings.TrimSpace(got), "\n")
+		if last := strings.Fields(lines[len(lines)-1]); last[len(last)-1] != "-" {
+			t.Errorf("expected %q for errored estimate, got line %q", "-", lines[len(lines)-1])
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("json", true)
+		got, err := captureStdout(t, func() error {
+			return runRunList(mock, "test-org", "my-ws", runListOptions{WithCost: true})
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(got, `"delta_monthly_cost": "3.5"`) {
+			t.Errorf("expected delta_monthly_cost in JSON output, got:\n%s", got)
+		}
+		if strings.Count(got, "delta_monthly_cost") != 1 {
+			t.Errorf("expected delta_monthly_cost only for finished estimates, got:\n%s", got)
+		}
+	})
+}
+
+func TestRunList_WithoutCost(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunListService{
+		workspace: &tfe.Workspace{ID: "ws-abc123", Name: "my-ws"},
+		listRunFn: func(opts *tfe.RunListOptions) (*tfe.RunList, error) {
+			if len(opts.Include) != 0 {
+				t.Errorf("expected no include, got %v", opts.Include)
+			}
+			return &tfe.RunList{}, nil
+		},
+	}
+	got, err := captureStdout(t, func() error {
+		return runRunList(mock, "test-org", "my-ws", runListOptions{})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(got, "COST DELTA") {
+		t.Errorf("expected no COST DELTA column, got:\n%s", got)
+	}
+}
+
+func newTestOrgRunsMock() *mockRunListService {
+	t0 := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	return &mockRunListService{
+		explorer: []client.ExplorerWorkspace{
+			{WorkspaceName: "prod-vpc", WorkspaceID: "ws-1", ProjectName: "network", Tags: []string{"env:prod"}},
+			{WorkspaceName: "dev-vpc", WorkspaceID: "ws-2", ProjectName: "network", Tags: []string{"env:dev"}},
+			{WorkspaceName: "billing", WorkspaceID: "ws-3", ProjectName: "apps", Tags: []string{"env:prod"}},
+		},
+		workspaceRuns: map[string][]*tfe.Run{
+			"ws-1": {
+				{ID: "run-1b", Status: tfe.RunErrored, CreatedAt: t0.Add(-10 * time.Minute)},
+				{ID: "run-1a", Status: tfe.RunApplied, CreatedAt: t0.Add(-3 * time.Hour)},
+			},
+			"ws-2": {
+				{ID: "run-2a", Status: tfe.RunPlanned, CreatedAt: t0.Add(-5 * time.Minute)},
+			},
+			"ws-3": {
+				{ID: "run-3a", Status: tfe.RunApplied, CreatedAt: t0.Add(-30 * time.Minute)},
+			},
+		},
+	}
+}
+
+func TestRunList_AllWorkspaces(t *testing.T) {
+	viper.Reset()
+
+	got, err := captureStdout(t, func() error {
+		return runRunList(newTestOrgRunsMock(), "test-org", "", runListOptions{AllWorkspaces: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if !strings.HasPrefix(lines[0], "WORKSPACE") {
+		t.Errorf("expected WORKSPACE as the first column, got %q", lines[0])
+	}
+	var order []string
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		order = append(order, fields[0]+"/"+fields[1])
+	}
+	want := "dev-vpc/run-2a,prod-vpc/run-1b,billing/run-3a,prod-vpc/run-1a"
+	if strings.Join(order, ",") != want {
+		t.Errorf("expected runs newest first %s, got %v", want, order)
+	}
+}
+
+func TestRunList_AllWorkspaces_Filters(t *testing.T) {
+	since := time.Date(2024, 3, 15, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		opts runListOptions
+		want []string
+	}{
+		{"project", runListOptions{Projects: []string{"network"}}, []string{"run-2a", "run-1b", "run-1a"}},
+		{"tag", runListOptions{Tags: []string{"env:prod"}}, []string{"run-1b", "run-3a", "run-1a"}},
+		{"tag key", runListOptions{Tags: []string{"env"}}, []string{"run-2a", "run-1b", "run-3a", "run-1a"}},
+		{"since", runListOptions{Since: since}, []string{"run-2a", "run-1b", "run-3a"}},
+		{"project and since", runListOptions{Projects: []string{"apps"}, Since: since}, []string{"run-3a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("json", true)
+
+			tt.opts.AllWorkspaces = true
+			got, err := captureStdout(t, func() error {
+				return runRunList(newTestOrgRunsMock(), "test-org", "", tt.opts)
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var items []runJSON
+			if err := json.Unmarshal([]byte(got), &items); err != nil {
+				t.Fatalf("failed to parse JSON output: %v\n%s", err, got)
+			}
+			var ids []string
+			for _, item := range items {
+				ids = append(ids, item.ID)
+				if item.Workspace == "" {
+					t.Errorf("expected workspace for run %s", item.ID)
+				}
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, ids)
+			}
+		})
+	}
+}
+
+func TestRunList_AllWorkspaces_Error(t *testing.T) {
+	viper.Reset()
+
+	mock := newTestOrgRunsMock()
+	mock.workspaceErr = map[string]error{"ws-3": fmt.Errorf("forbidden")}
+
+	_, err := captureStdout(t, func() error {
+		return runRunList(mock, "test-org", "", runListOptions{AllWorkspaces: true})
+	})
+	if err == nil || !strings.Contains(err.Error(), `workspace "billing"`) || !strings.Contains(err.Error(), "forbidden") {
+		t.Errorf("expected error for workspace billing, got %v", err)
+	}
+}
+
+func TestRunList_Since_StopsPaging(t *testing.T) {
+	viper.Reset()
+
+	t0 := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	pages := 0
+	mock := &mockRunListService{
+		workspace: &tfe.Workspace{ID: "ws-abc123", Name: "my-ws"},
+		listRunFn: func(opts *tfe.RunListOptions) (*tfe.RunList, error) {
+			pages++
+			return &tfe.RunList{
+				Items: []*tfe.Run{
+					{ID: "run-new", CreatedAt: t0},
+					{ID: "run-old", CreatedAt: t0.Add(-2 * time.Hour)},
+				},
+				Pagination: &tfe.Pagination{NextPage: 2},
+			}, nil
+		},
+	}
+
+	got, err := captureStdout(t, func() error {
+		return runRunList(mock, "test-org", "my-ws", runListOptions{Since: t0.Add(-time.Hour)})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pages != 1 {
+		t.Errorf("expected paging to stop after 1 page, got %d", pages)
+	}
+	if !strings.Contains(got, "run-new") || strings.Contains(got, "run-old") {
+		t.Errorf("expected only run-new, got:\n%s", got)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseSince("1h", now)
+	if err != nil || !got.Equal(now.Add(-time.Hour)) {
+		t.Errorf("parseSince(1h) = %v, %v", got, err)
+	}
+	got, err = parseSince("30d", now)
+	if err != nil || !got.Equal(time.Date(2024, 2, 14, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseSince(30d) = %v, %v", got, err)
+	}
+	got, err = parseSince("2024-03-14T00:00:00Z", now)
+	if err != nil || !got.Equal(time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseSince(RFC 3339) = %v, %v", got, err)
+	}
+	for _, s := range []string{"yesterday", "-1h", "0s", "0d", "xd"} {
+		if _, err := parseSince(s, now); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
+
+func TestRunList_AllWorkspaces_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"workspace and all-workspaces", []string{"-w", "my-ws", "--all-workspaces"}, "cannot specify both --workspace/-w and --all-workspaces"},
+		{"project without all-workspaces", []string{"-w", "my-ws", "--project", "network"}, "--project and --tag require --all-workspaces"},
+		{"tag without all-workspaces", []string{"-w", "my-ws", "--tag", "env:prod"}, "--project and --tag require --all-workspaces"},
+		{"invalid since", []string{"--all-workspaces", "--since", "yesterday"}, `invalid --since "yesterday"`},
+		{"all-workspaces without since", []string{"--all-workspaces", "--project", "network"}, "--all-workspaces requires --since"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("org", "test-org")
+
+			cmd := newCmdRunListWith(func() (runListService, error) {
+				return newTestOrgRunsMock(), nil
+			})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// rawLogLine is a line of Terraform's machine-readable (-json) UI output.
+type rawLogLine struct {
+	Level      string         `json:"@level"`
+	Message    string         `json:"@message"`
+	Module     string         `json:"@module"`
+	Timestamp  string         `json:"@timestamp"`
+	Type       string         `json:"type"`
+	Hook       *rawLogHook    `json:"hook"`
+	Diagnostic *logDiagnostic `json:"diagnostic"`
+}
+
+type rawLogHook struct {
+	Resource *struct {
+		Addr string `json:"addr"`
+	} `json:"resource"`
+	Action         string  `json:"action"`
+	IDKey          string  `json:"id_key"`
+	IDValue        string  `json:"id_value"`
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+}
+
+// logDiagnostic is a Terraform diagnostic (error or warning).
+type logDiagnostic struct {
+	Severity string          `json:"severity"`
+	Summary  string          `json:"summary"`
+	Detail   string          `json:"detail,omitempty"`
+	Address  string          `json:"address,omitempty"`
+	Range    *logSourceRange `json:"range,omitempty"`
+	Snippet  *logCodeSnippet `json:"snippet,omitempty"`
+}
+
+type logSourceRange struct {
+	Filename string `json:"filename"`
+	Start    struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"start"`
+}
+
+type logCodeSnippet struct {
+	Context   string `json:"context,omitempty"`
+	Code      string `json:"code"`
+	StartLine int    `json:"start_line"`
+}
+
+// logEvent is a normalized log line, emitted as-is with --json.
+type logEvent struct {
+	Timestamp  string         `json:"timestamp,omitempty"`
+	Level      string         `json:"level"`
+	Type       string         `json:"type"`
+	Message    string         `json:"message"`
+	Resource   string         `json:"resource,omitempty"`
+	Action     string         `json:"action,omitempty"`
+	ID         string         `json:"id,omitempty"`
+	Elapsed    *float64       `json:"elapsed_seconds,omitempty"`
+	Diagnostic *logDiagnostic `json:"diagnostic,omitempty"`
+}
+
+// parseLogLine converts a log line into an event. Lines that are not
+// Terraform JSON UI output become "output" events carrying the raw text.
+func parseLogLine(line string) logEvent {
+	var raw rawLogLine
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") || json.Unmarshal([]byte(line), &raw) != nil || raw.Level == "" {
+		return logEvent{Level: "info", Type: "output", Message: line}
+	}
+
+	ev := logEvent{
+		Timestamp:  raw.Timestamp,
+		Level:      raw.Level,
+		Type:       raw.Type,
+		Message:    raw.Message,
+		Diagnostic: raw.Diagnostic,
+	}
+	if ev.Type == "" {
+		ev.Type = "log"
+	}
+	if raw.Hook != nil {
+		if raw.Hook.Resource != nil {
+			ev.Resource = raw.Hook.Resource.Addr
+		}
+		ev.Action = raw.Hook.Action
+		ev.ID = raw.Hook.IDValue
+		if raw.Hook.ElapsedSeconds > 0 {
+			elapsed := raw.Hook.ElapsedSeconds
+			ev.Elapsed = &elapsed
+		}
+	}
+	return ev
+}
+
+// isError reports whether the event is an error-level line or diagnostic.
+func (ev logEvent) isError() bool {
+	if ev.Diagnostic != nil {
+		return ev.Diagnostic.Severity == "error"
+	}
+	return ev.Level == "error"
+}
+
+// renderLogEvent writes an event the way the terraform CLI displays it.
+func renderLogEvent(w io.Writer, ev logEvent) {
+	if ev.Diagnostic != nil {
+		renderDiagnostic(w, ev.Diagnostic)
+		return
+	}
+
+	// Terraform's UI messages already carry resource progress and elapsed
+	// times, e.g. "aws_instance.web: Still creating... [10s elapsed]".
+	_, _ = fmt.Fprintln(w, ev.Message)
+}
+
+// renderDiagnostic writes a diagnostic in the boxed terraform CLI format:
+//
+//	╷
+//	│ Error: summary
+//	│
+//	│   on main.tf line 3, in resource "x" "y":
+//	│    3:   foo = "bar"
+//	│
+//	│ detail
+//	╵
+func renderDiagnostic(w io.Writer, d *logDiagnostic) {
+	severity := "Error"
+	if d.Severity == "warning" {
+		severity = "Warning"
+	}
+
+	lines := []string{fmt.Sprintf("%s: %s", severity, d.Summary)}
+	if d.Address != "" {
+		lines = append(lines, "", "  with "+d.Address+",")
+	}
+	if d.Range != nil && d.Range.Filename != "" {
+		location := fmt.Sprintf("  on %s line %d", d.Range.Filename, d.Range.Start.Line)
+		if d.Snippet != nil && d.Snippet.Context != "" {
+			location += ", in " + d.Snippet.Context
+		}
+		if d.Address == "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, location+":")
+		if d.Snippet != nil {
+			for i, code := range strings.Split(d.Snippet.Code, "\n") {
+				lines = append(lines, fmt.Sprintf("%4d: %s", d.Snippet.StartLine+i, code))
+			}
+		}
+	}
+	if d.Detail != "" {
+		lines = append(lines, "")
+		lines = append(lines, strings.Split(d.Detail, "\n")...)
+	}
+
+	_, _ = fmt.Fprintln(w, "╷")
+	for _, line := range lines {
+		if line == "" {
+			_, _ = fmt.Fprintln(w, "│")
+			continue
+		}
+		_, _ = fmt.Fprintln(w, "│ "+line)
+	}
+	_, _ = fmt.Fprintln(w, "╵")
+}
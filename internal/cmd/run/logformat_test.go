@@ -0,0 +1,138 @@
+package run
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+const testApplyLog = `Terraform v1.9.0
+{"@level":"info","@message":"aws_instance.web: Creating...","@module":"terraform.ui","@timestamp":"2024-03-15T12:00:00.000000Z","hook":{"resource":{"addr":"aws_instance.web"},"action":"create"},"type":"apply_start"}
+{"@level":"info","@message":"aws_instance.web: Creation complete after 12s [id=i-0abc]","@module":"terraform.ui","@timestamp":"2024-03-15T12:00:12.000000Z","hook":{"resource":{"addr":"aws_instance.web"},"action":"create","id_key":"id","id_value":"i-0abc","elapsed_seconds":12},"type":"apply_complete"}
+{"@level":"error","@message":"Error: Invalid AMI","@module":"terraform.ui","@timestamp":"2024-03-15T12:00:13.000000Z","diagnostic":{"severity":"error","summary":"Invalid AMI","detail":"The AMI ami-123 does not exist.","address":"aws_instance.db","range":{"filename":"main.tf","start":{"line":12,"column":9}},"snippet":{"context":"resource \"aws_instance\" \"db\"","code":"  ami = \"ami-123\"","start_line":12}},"type":"diagnostic"}`
+
+func TestParseLogLine(t *testing.T) {
+	lines := strings.Split(testApplyLog, "\n")
+
+	plain := parseLogLine(lines[0])
+	if plain.Type != "output" || plain.Level != "info" || plain.Message != "Terraform v1.9.0" {
+		t.Errorf("unexpected plain event: %+v", plain)
+	}
+
+	complete := parseLogLine(lines[2])
+	if complete.Type != "apply_complete" || complete.Resource != "aws_instance.web" || complete.Action != "create" || complete.ID != "i-0abc" {
+		t.Errorf("unexpected apply_complete event: %+v", complete)
+	}
+	if complete.Elapsed == nil || *complete.Elapsed != 12 {
+		t.Errorf("expected elapsed 12, got %v", complete.Elapsed)
+	}
+
+	diag := parseLogLine(lines[3])
+	if !diag.isError() || diag.Diagnostic == nil || diag.Diagnostic.Summary != "Invalid AMI" {
+		t.Errorf("unexpected diagnostic event: %+v", diag)
+	}
+
+	// JSON that is not a Terraform log line is treated as plain output
+	other := parseLogLine(`{"foo":"bar"}`)
+	if other.Type != "output" || other.Message != `{"foo":"bar"}` {
+		t.Errorf("unexpected event for non-log JSON: %+v", other)
+	}
+}
+
+func TestPrintLogs_Rendered(t *testing.T) {
+	viper.Reset()
+
+	var buf bytes.Buffer
+	if err := printLogs(&buf, strings.NewReader(testApplyLog), runLogsOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `Terraform v1.9.0
+aws_instance.web: Creating...
+aws_instance.web: Creation complete after 12s [id=i-0abc]
+╷
+│ Error: Invalid AMI
+│
+│   with aws_instance.db,
+│   on main.tf line 12, in resource "aws_instance" "db":
+│   12:   ami = "ami-123"
+│
+│ The AMI ami-123 does not exist.
+╵
+`
+	if buf.String() != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintLogs_Raw(t *testing.T) {
+	viper.Reset()
+
+	var buf bytes.Buffer
+	if err := printLogs(&buf, strings.NewReader(testApplyLog), runLogsOptions{Raw: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != testApplyLog+"\n" {
+		t.Errorf("expected raw output to be unchanged, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintLogs_JSON(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	var buf bytes.Buffer
+	if err := printLogs(&buf, strings.NewReader(testApplyLog), runLogsOptions{ErrorOnly: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 event, got %d:\n%s", len(lines), buf.String())
+	}
+
+	var ev logEvent
+	if err := json.Unmarshal([]byte(lines[0]), &ev); err != nil {
+		t.Fatalf("failed to parse event: %v", err)
+	}
+	if ev.Type != "diagnostic" || ev.Level != "error" || ev.Diagnostic == nil || ev.Diagnostic.Range.Filename != "main.tf" {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+}
+
+func TestRenderDiagnostic_Warning(t *testing.T) {
+	var buf bytes.Buffer
+	renderDiagnostic(&buf, &logDiagnostic{Severity: "warning", Summary: "Deprecated attribute", Detail: "line one\nline two"})
+
+	want := "╷\n│ Warning: Deprecated attribute\n│\n│ line one\n│ line two\n╵\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\ngot:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestRunLogs_RawWithJSON(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	cmd := newCmdRunLogsWith(func() (runLogsService, error) {
+		return &mockRunLogsService{}, nil
+	})
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"run-abc123", "--raw"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "--raw cannot be used with --json") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -3,10 +3,13 @@ package run
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/spf13/cobra"
@@ -15,11 +18,28 @@ import (
 	"github.com/nnstt1/hcpt/internal/client"
 )
 
-// runLogsService combines the services needed to fetch apply logs.
+// runLogsService combines the services needed to fetch plan and apply logs.
 type runLogsService interface {
 	client.RunService
 	client.WorkspaceService
+	client.PlanService
 	client.ApplyService
+	client.LogService
+}
+
+// Log phases accepted by --phase.
+const (
+	logPhasePlan  = "plan"
+	logPhaseApply = "apply"
+	logPhaseAll   = "all"
+)
+
+// runLogsOptions holds the flags for run logs.
+type runLogsOptions struct {
+	Phase     string // plan, apply, all, or empty to pick automatically
+	ErrorOnly bool
+	Follow    bool
+	Raw       bool // print log lines exactly as received
 }
 
 type runLogsClientFactory func() (runLogsService, error)
@@ -34,11 +54,27 @@ func newCmdRunLogs() *cobra.Command {
 
 func newCmdRunLogsWith(clientFn runLogsClientFactory) *cobra.Command {
 	var workspaceName string
-	var errorOnly bool
+	var mrIID int
+	var project string
+	var opts runLogsOptions
 
 	cmd := &cobra.Command{
-		Use:          "logs [run-id]",
-		Short:        "Show apply logs for a run",
+		Use:   "logs [run-id]",
+		Short: "Show plan or apply logs for a run",
+		Long: `Show plan or apply logs for a run.
+
+By default the logs of the phase that failed are shown: plan logs when the
+plan errored or the run has not reached the apply, otherwise apply logs.
+Use --phase to choose explicitly.
+
+With --follow, the logs are streamed while the run is in progress, moving from
+the plan to the apply automatically. Following stops when the run finishes
+or awaits confirmation or a policy override, and the command exits with the
+codes of run show --watch.
+
+Terraform's JSON log lines are rendered like the terraform CLI output. Use
+--raw to print the log lines as received, or --json to print one normalized
+JSON event per line.`,
 		Args:         cobra.MaximumNArgs(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -47,8 +83,39 @@ func newCmdRunLogsWith(clientFn runLogsClientFactory) *cobra.Command {
 				runID = args[0]
 			}
 
-			if runID == "" && workspaceName == "" {
-				return fmt.Errorf("either run-id or --workspace/-w is required")
+			if runID != "" && mrIID > 0 {
+				return fmt.Errorf("cannot specify both run-id and --mr")
+			}
+			if project != "" && mrIID == 0 {
+				return fmt.Errorf("--repo requires --mr")
+			}
+
+			if runID == "" && mrIID == 0 && workspaceName == "" {
+				return fmt.Errorf("either run-id, --mr, or --workspace/-w is required")
+			}
+
+			if opts.Raw && viper.GetBool("json") {
+				return fmt.Errorf("--raw cannot be used with --json")
+			}
+
+			switch opts.Phase {
+			case "", logPhasePlan, logPhaseApply, logPhaseAll:
+			default:
+				return fmt.Errorf("invalid --phase %q: must be one of plan, apply, all", opts.Phase)
+			}
+
+			if mrIID > 0 {
+				if project == "" {
+					// Try to auto-detect project from Git remote
+					detectedProject, err := client.DetectGitLabProject(context.Background())
+					if err != nil {
+						return err
+					}
+					project = detectedProject
+				}
+				if !strings.Contains(project, "/") {
+					return fmt.Errorf("--repo must be in format 'group/project'")
+				}
 			}
 
 			svc, err := clientFn()
@@ -56,18 +123,35 @@ func newCmdRunLogsWith(clientFn runLogsClientFactory) *cobra.Command {
 				return err
 			}
 
-			return runRunLogs(svc, runID, viper.GetString("org"), workspaceName, errorOnly)
+			if mrIID > 0 {
+				runID, err = resolveRunIDFromMR(context.Background(), project, mrIID, workspaceName)
+				if err != nil {
+					return err
+				}
+			}
+
+			return runRunLogs(svc, runID, viper.GetString("org"), workspaceName, opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&workspaceName, "workspace", "w", "", "Workspace name (uses latest run)")
-	cmd.Flags().BoolVar(&errorOnly, "error-only", false, "Show only error-level log lines")
+	cmd.Flags().IntVar(&mrIID, "mr", 0, "GitLab merge request IID (uses the run reported on it)")
+	cmd.Flags().StringVarP(&project, "repo", "r", "", "GitLab project (group/project) for --mr")
+	cmd.Flags().StringVar(&opts.Phase, "phase", "", "Log phase to show: plan, apply, or all (default: the phase that failed)")
+	cmd.Flags().BoolVar(&opts.ErrorOnly, "error-only", false, "Show only error-level log lines")
+	cmd.Flags().BoolVarP(&opts.Follow, "follow", "f", false, "Stream logs until the run completes")
+	cmd.Flags().BoolVar(&opts.Raw, "raw", false, "Print log lines as received without rendering")
 
 	return cmd
 }
 
-func runRunLogs(svc runLogsService, runID, org, workspaceName string, errorOnly bool) error {
-	ctx := context.Background()
+func runRunLogs(svc runLogsService, runID, org, workspaceName string, opts runLogsOptions) error {
+	return runRunLogsWithInterval(svc, runID, org, workspaceName, opts, 2*time.Second)
+}
+
+func runRunLogsWithInterval(svc runLogsService, runID, org, workspaceName string, opts runLogsOptions, pollInterval time.Duration) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
 
 	// If no run ID given, get the latest run from the workspace
 	if runID == "" {
@@ -94,14 +178,80 @@ func runRunLogs(svc runLogsService, runID, org, workspaceName string, errorOnly
 		runID = runList.Items[0].ID
 	}
 
-	// Read the run including the apply
+	if opts.Follow {
+		return followRunLogs(ctx, svc, runID, opts, pollInterval)
+	}
+
+	// Read the run including the plan and apply
 	r, err := svc.ReadRunWithApply(ctx, runID)
 	if err != nil {
 		return fmt.Errorf("failed to read run: %w", err)
 	}
 
+	phase := opts.Phase
+	if phase == "" {
+		phase = defaultLogPhase(r)
+	}
+
+	switch phase {
+	case logPhasePlan:
+		return printPlanLogs(ctx, svc, r, opts)
+	case logPhaseApply:
+		return printApplyLogs(ctx, svc, r, opts)
+	default:
+		printPhaseHeader(os.Stdout, logPhasePlan)
+		if err := printPlanLogs(ctx, svc, r, opts); err != nil {
+			return err
+		}
+		if !hasApplyStarted(r) {
+			return nil
+		}
+		printPhaseHeader(os.Stdout, logPhaseApply)
+		return printApplyLogs(ctx, svc, r, opts)
+	}
+}
+
+// defaultLogPhase picks the phase whose logs are most relevant: the plan when
+// it errored or the apply never started, otherwise the apply.
+func defaultLogPhase(r *tfe.Run) string {
+	if r.Plan != nil && r.Plan.Status == tfe.PlanErrored {
+		return logPhasePlan
+	}
+	if r.Plan != nil && !hasApplyStarted(r) {
+		return logPhasePlan
+	}
+	return logPhaseApply
+}
+
+// hasApplyStarted reports whether the run's apply has produced (or is producing) logs.
+func hasApplyStarted(r *tfe.Run) bool {
+	if r.Apply == nil {
+		return false
+	}
+	switch r.Apply.Status {
+	case tfe.ApplyPending, tfe.ApplyUnreachable:
+		return false
+	default:
+		return true
+	}
+}
+
+func printPlanLogs(ctx context.Context, svc runLogsService, r *tfe.Run, opts runLogsOptions) error {
+	if r.Plan == nil {
+		return fmt.Errorf("run %q does not have a plan (status: %s)", r.ID, r.Status)
+	}
+
+	logs, err := svc.ReadPlanLogs(ctx, r.Plan.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read plan logs: %w", err)
+	}
+
+	return printLogs(os.Stdout, logs, opts)
+}
+
+func printApplyLogs(ctx context.Context, svc runLogsService, r *tfe.Run, opts runLogsOptions) error {
 	if r.Apply == nil {
-		return fmt.Errorf("run %q does not have an apply (status: %s)", runID, r.Status)
+		return fmt.Errorf("run %q does not have an apply (status: %s)", r.ID, r.Status)
 	}
 
 	logs, err := svc.ReadApplyLogs(ctx, r.Apply.ID)
@@ -109,21 +259,56 @@ func runRunLogs(svc runLogsService, runID, org, workspaceName string, errorOnly
 		return fmt.Errorf("failed to read apply logs: %w", err)
 	}
 
-	return printLogs(os.Stdout, logs, errorOnly)
+	return printLogs(os.Stdout, logs, opts)
 }
 
-func printLogs(w io.Writer, r io.Reader, errorOnly bool) error {
+// printPhaseHeader separates the plan and apply logs. It prints nothing in
+// JSON mode so that the output stays one event per line.
+func printPhaseHeader(w io.Writer, phase string) {
+	if viper.GetBool("json") {
+		return
+	}
+	if phase == logPhasePlan {
+		_, _ = fmt.Fprintln(w, "==> Plan")
+		return
+	}
+	_, _ = fmt.Fprintln(w, "==> Apply")
+}
+
+// maxLogLineSize bounds a single log line; diagnostics can exceed bufio's default.
+const maxLogLineSize = 1024 * 1024
+
+func printLogs(w io.Writer, r io.Reader, opts runLogsOptions) error {
+	jsonOutput := viper.GetBool("json")
+	enc := json.NewEncoder(w)
+
 	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLogLineSize)
 	for scanner.Scan() {
 		line := scanner.Text()
-		if errorOnly && !isErrorLine(line) {
+		if opts.Raw {
+			if opts.ErrorOnly && !isErrorLine(line) {
+				continue
+			}
+			_, _ = fmt.Fprintln(w, line)
+			continue
+		}
+
+		ev := parseLogLine(line)
+		if opts.ErrorOnly && !ev.isError() {
+			continue
+		}
+		if jsonOutput {
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
 			continue
 		}
-		_, _ = fmt.Fprintln(w, line)
+		renderLogEvent(w, ev)
 	}
 	return scanner.Err()
 }
 
 func isErrorLine(line string) bool {
-	return strings.Contains(line, `"@level":"error"`)
+	return parseLogLine(line).isError()
 }
@@ -3,11 +3,13 @@ package run
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/spf13/viper"
@@ -22,6 +24,11 @@ type mockRunLogsService struct {
 	runErr       error
 	logs         string
 	logsErr      error
+	planLogs     string
+	planLogsErr  error
+	runs         []*tfe.Run // runs returned by ReadRunWithApply in sequence (for --follow)
+	readCount    int
+	logContents  map[string]string // log content by log-read-url (for --follow)
 }
 
 func (m *mockRunLogsService) ListRuns(_ context.Context, _ string, _ *tfe.RunListOptions) (*tfe.RunList, error) {
@@ -42,9 +49,18 @@ func (m *mockRunLogsService) ReadRunWithApply(_ context.Context, _ string) (*tfe
 	if m.runErr != nil {
 		return nil, m.runErr
 	}
+	if len(m.runs) > 0 {
+		r := m.runs[min(m.readCount, len(m.runs)-1)]
+		m.readCount++
+		return r, nil
+	}
 	return m.run, nil
 }
 
+func (m *mockRunLogsService) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, fmt.Errorf("CreateRun not implemented in mockRunLogsService")
+}
+
 func (m *mockRunLogsService) ListWorkspaces(_ context.Context, _ string, _ *tfe.WorkspaceListOptions) (*tfe.WorkspaceList, error) {
 	return nil, nil
 }
@@ -56,6 +72,28 @@ func (m *mockRunLogsService) ReadWorkspace(_ context.Context, _, _ string) (*tfe
 	return m.workspace, nil
 }
 
+func (m *mockRunLogsService) ReadPlanJSONOutput(_ context.Context, _ string) ([]byte, error) {
+	return nil, fmt.Errorf("ReadPlanJSONOutput not implemented in mockRunLogsService")
+}
+
+func (m *mockRunLogsService) ReadPlanLogs(_ context.Context, _ string) (io.Reader, error) {
+	if m.planLogsErr != nil {
+		return nil, m.planLogsErr
+	}
+	return strings.NewReader(m.planLogs), nil
+}
+
+func (m *mockRunLogsService) ReadLogChunk(_ context.Context, logReadURL string, offset int64) ([]byte, error) {
+	content, ok := m.logContents[logReadURL]
+	if !ok {
+		return nil, fmt.Errorf("unknown log URL %q", logReadURL)
+	}
+	if offset >= int64(len(content)) {
+		return nil, nil
+	}
+	return []byte(content[offset:]), nil
+}
+
 func (m *mockRunLogsService) ReadApplyLogs(_ context.Context, _ string) (io.Reader, error) {
 	if m.logsErr != nil {
 		return nil, m.logsErr
@@ -83,7 +121,7 @@ func TestRunLogs_Basic(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunLogs(mock, "run-abc123", "", "", false)
+	err := runRunLogs(mock, "run-abc123", "", "", runLogsOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -125,7 +163,7 @@ func TestRunLogs_ErrorOnly(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunLogs(mock, "run-abc123", "", "", true)
+	err := runRunLogs(mock, "run-abc123", "", "", runLogsOptions{ErrorOnly: true})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -185,7 +223,7 @@ func TestRunLogs_WithWorkspace(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunLogs(mock, "", "test-org", "my-ws", false)
+	err := runRunLogs(mock, "", "test-org", "my-ws", runLogsOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -214,7 +252,7 @@ func TestRunLogs_NoApply(t *testing.T) {
 		},
 	}
 
-	err := runRunLogs(mock, "run-planning", "", "", false)
+	err := runRunLogs(mock, "run-planning", "", "", runLogsOptions{})
 	if err == nil {
 		t.Fatal("expected error when apply is nil")
 	}
@@ -240,7 +278,7 @@ func TestRunLogs_NoArgs(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
-	if !strings.Contains(err.Error(), "either run-id or --workspace/-w is required") {
+	if !strings.Contains(err.Error(), "either run-id, --mr, or --workspace/-w is required") {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
@@ -299,7 +337,7 @@ func TestRunLogs_WithWorkspace_NoRuns(t *testing.T) {
 		runList:   &tfe.RunList{Items: []*tfe.Run{}},
 	}
 
-	err := runRunLogs(mock, "", "test-org", "empty-ws", false)
+	err := runRunLogs(mock, "", "test-org", "empty-ws", runLogsOptions{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -308,6 +346,320 @@ func TestRunLogs_WithWorkspace_NoRuns(t *testing.T) {
 	}
 }
 
+func TestRunLogs_Phase(t *testing.T) {
+	planErrored := &tfe.Run{
+		ID:     "run-abc123",
+		Status: tfe.RunErrored,
+		Plan:   &tfe.Plan{ID: "plan-abc123", Status: tfe.PlanErrored},
+		Apply:  &tfe.Apply{ID: "apply-abc123", Status: tfe.ApplyUnreachable},
+	}
+	applied := &tfe.Run{
+		ID:     "run-abc123",
+		Status: tfe.RunApplied,
+		Plan:   &tfe.Plan{ID: "plan-abc123", Status: tfe.PlanFinished},
+		Apply:  &tfe.Apply{ID: "apply-abc123", Status: tfe.ApplyFinished},
+	}
+
+	tests := []struct {
+		name    string
+		run     *tfe.Run
+		opts    runLogsOptions
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "default to plan when plan errored",
+			run:     planErrored,
+			want:    []string{"plan log line"},
+			notWant: []string{"apply log line", "==> Plan"},
+		},
+		{
+			name:    "default to apply when apply ran",
+			run:     applied,
+			want:    []string{"apply log line"},
+			notWant: []string{"plan log line"},
+		},
+		{
+			name:    "explicit plan phase",
+			run:     applied,
+			opts:    runLogsOptions{Phase: logPhasePlan},
+			want:    []string{"plan log line"},
+			notWant: []string{"apply log line"},
+		},
+		{
+			name: "all phases",
+			run:  applied,
+			opts: runLogsOptions{Phase: logPhaseAll},
+			want: []string{"==> Plan", "plan log line", "==> Apply", "apply log line"},
+		},
+		{
+			name:    "all phases skips apply that never started",
+			run:     planErrored,
+			opts:    runLogsOptions{Phase: logPhaseAll},
+			want:    []string{"==> Plan", "plan log line"},
+			notWant: []string{"==> Apply", "apply log line"},
+		},
+		{
+			name:    "error-only across phases",
+			run:     applied,
+			opts:    runLogsOptions{Phase: logPhaseAll, ErrorOnly: true},
+			want:    []string{"plan failure", "apply failure"},
+			notWant: []string{"plan log line", "apply log line"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			mock := &mockRunLogsService{
+				run:      tt.run,
+				planLogs: "plan log line\n" + `{"@level":"error","@message":"plan failure"}`,
+				logs:     "apply log line\n" + `{"@level":"error","@message":"apply failure"}`,
+			}
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runRunLogs(mock, "run-abc123", "", "", tt.opts)
+
+			_ = w.Close()
+			os.Stdout = oldStdout
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+			got := buf.String()
+
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected %q in output, got:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("expected %q not in output, got:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRunLogs_PlanLogsError(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunLogsService{
+		run: &tfe.Run{
+			ID:     "run-abc123",
+			Status: tfe.RunErrored,
+			Plan:   &tfe.Plan{ID: "plan-abc123", Status: tfe.PlanErrored},
+		},
+		planLogsErr: fmt.Errorf("archivist unavailable"),
+	}
+
+	err := runRunLogs(mock, "run-abc123", "", "", runLogsOptions{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "failed to read plan logs") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunLogs_InvalidPhase(t *testing.T) {
+	viper.Reset()
+
+	cmd := newCmdRunLogsWith(func() (runLogsService, error) {
+		return &mockRunLogsService{}, nil
+	})
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"run-abc123", "--phase", "refresh"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "invalid --phase") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// followRun builds a run snapshot for --follow tests. Log URLs encode the
+// snapshot number so that each poll can expose more output.
+func followRun(n int, status tfe.RunStatus, planStatus tfe.PlanStatus, applyStatus tfe.ApplyStatus) *tfe.Run {
+	return &tfe.Run{
+		ID:     "run-abc123",
+		Status: status,
+		Plan:   &tfe.Plan{ID: "plan-abc123", Status: planStatus, LogReadURL: fmt.Sprintf("plan-%d", n)},
+		Apply:  &tfe.Apply{ID: "apply-abc123", Status: applyStatus, LogReadURL: fmt.Sprintf("apply-%d", n)},
+	}
+}
+
+func captureFollow(t *testing.T, mock *mockRunLogsService, opts runLogsOptions) (string, error) {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	opts.Follow = true
+	err := runRunLogsWithInterval(mock, "run-abc123", "", "", opts, time.Millisecond)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+func TestRunLogs_Follow(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunLogsService{
+		runs: []*tfe.Run{
+			followRun(0, tfe.RunPlanning, tfe.PlanRunning, tfe.ApplyPending),
+			followRun(1, tfe.RunApplying, tfe.PlanFinished, tfe.ApplyRunning),
+			followRun(2, tfe.RunApplied, tfe.PlanFinished, tfe.ApplyFinished),
+		},
+		logContents: map[string]string{
+			"plan-0":  "\x02plan line 1\nplan li",
+			"plan-1":  "\x02plan line 1\nplan line 2\n\x03",
+			"apply-1": "\x02apply line 1\n",
+			"apply-2": "\x02apply line 1\napply line 2\n\x03",
+		},
+	}
+
+	got, err := captureFollow(t, mock, runLogsOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "==> Plan\nplan line 1\nplan line 2\n==> Apply\napply line 1\napply line 2\n"
+	if got != want {
+		t.Errorf("unexpected output:\ngot:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestRunLogs_FollowPlanErrored(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunLogsService{
+		runs: []*tfe.Run{
+			followRun(0, tfe.RunErrored, tfe.PlanErrored, tfe.ApplyUnreachable),
+		},
+		logContents: map[string]string{
+			"plan-0": "\x02info line\n" + `{"@level":"error","@message":"Error: invalid reference"}` + "\x03",
+		},
+	}
+
+	got, err := captureFollow(t, mock, runLogsOptions{ErrorOnly: true})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitCodeErrored {
+		t.Fatalf("expected exit code %d for errored run, got %v", exitCodeErrored, err)
+	}
+	if !strings.Contains(err.Error(), "finished with status errored") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(got, "invalid reference") {
+		t.Errorf("expected error line in output, got:\n%s", got)
+	}
+	if strings.Contains(got, "info line") || strings.Contains(got, "==> Apply") {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+}
+
+func TestRunLogs_FollowPlanPhaseOnly(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunLogsService{
+		runs: []*tfe.Run{
+			followRun(0, tfe.RunPlanned, tfe.PlanFinished, tfe.ApplyPending),
+		},
+		logContents: map[string]string{
+			"plan-0": "\x02plan done\x03",
+		},
+	}
+
+	got, err := captureFollow(t, mock, runLogsOptions{Phase: logPhasePlan})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "plan done\n" {
+		t.Errorf("unexpected output: %q", got)
+	}
+}
+
+func TestRunLogs_FollowInterrupted(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunLogsService{
+		runs: []*tfe.Run{
+			followRun(0, tfe.RunPlanning, tfe.PlanRunning, tfe.ApplyPending),
+		},
+		logContents: map[string]string{
+			"plan-0": "\x02planning\n",
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := captureStdout(t, func() error {
+		return followRunLogs(ctx, mock, "run-abc123", runLogsOptions{Follow: true}, time.Millisecond)
+	})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitCodeInterrupted {
+		t.Fatalf("expected exit code %d, got %v", exitCodeInterrupted, err)
+	}
+	if !strings.Contains(err.Error(), "interrupted while watching run run-abc123 (status: planning)") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestRunLogs_FollowExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      *tfe.Run
+		wantCode int
+	}{
+		{"errored", followRun(0, tfe.RunErrored, tfe.PlanErrored, tfe.ApplyUnreachable), exitCodeErrored},
+		{"canceled", followRun(0, tfe.RunCanceled, tfe.PlanCanceled, tfe.ApplyUnreachable), exitCodeCanceled},
+		{"discarded", followRun(0, tfe.RunDiscarded, tfe.PlanFinished, tfe.ApplyUnreachable), exitCodeDiscarded},
+		{"awaiting confirmation", followRun(0, tfe.RunPlanned, tfe.PlanFinished, tfe.ApplyPending), exitCodeNeedsConfirmation},
+		{"policy soft failed", followRun(0, tfe.RunPolicySoftFailed, tfe.PlanFinished, tfe.ApplyPending), exitCodePolicySoftFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			mock := &mockRunLogsService{
+				runs:        []*tfe.Run{tt.run},
+				logContents: map[string]string{"plan-0": "\x02plan done\n\x03"},
+			}
+
+			got, err := captureFollow(t, mock, runLogsOptions{})
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) || exitErr.Code != tt.wantCode {
+				t.Fatalf("expected exit code %d, got %v", tt.wantCode, err)
+			}
+			if strings.Contains(got, "==> Apply") {
+				t.Errorf("expected the apply not to be followed, got:\n%s", got)
+			}
+		})
+	}
+}
+
 func TestIsErrorLine(t *testing.T) {
 	tests := []struct {
 		line     string
@@ -327,3 +679,32 @@ func TestIsErrorLine(t *testing.T) {
 		}
 	}
 }
+
+func TestRunLogs_MRValidation(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"run-abc123", "--mr", "7"}, "cannot specify both run-id and --mr"},
+		{[]string{"run-abc123", "--repo", "group/project"}, "--repo requires --mr"},
+		{[]string{"--mr", "7", "--repo", "project"}, "--repo must be in format 'group/project'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantErr, func(t *testing.T) {
+			viper.Reset()
+
+			cmd := newCmdRunLogsWith(func() (runLogsService, error) {
+				return &mockRunLogsService{}, nil
+			})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected %q error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package run
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/output"
+)
+
+// Policy frameworks.
+const (
+	policyKindSentinel = "sentinel"
+	policyKindOPA      = "opa"
+)
+
+// policyResult is the outcome of a single policy evaluated for a run.
+// Output holds the failure output and is empty for passed policies.
+type policyResult struct {
+	PolicySet        string `json:"policy_set"`
+	Policy           string `json:"policy"`
+	Kind             string `json:"kind"`
+	EnforcementLevel string `json:"enforcement_level"`
+	Status           string `json:"status"`
+	Output           string `json:"output,omitempty"`
+}
+
+// runPoliciesService combines RunService and PolicyService for policy results.
+type runPoliciesService interface {
+	client.RunService
+	client.PolicyService
+}
+
+type runPoliciesClientFactory func() (runPoliciesService, error)
+
+func defaultRunPoliciesClientFactory() (runPoliciesService, error) {
+	return client.NewClientWrapper()
+}
+
+func newCmdRunPolicies() *cobra.Command {
+	return newCmdRunPoliciesWith(defaultRunPoliciesClientFactory)
+}
+
+func newCmdRunPoliciesWith(clientFn runPoliciesClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "policies <run-id>",
+		Short:        "Show policy check results for a run",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := clientFn()
+			if err != nil {
+				return err
+			}
+			return runRunPolicies(svc, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runRunPolicies(svc runPoliciesService, runID string) error {
+	ctx := context.Background()
+
+	r, err := svc.ReadRun(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("failed to read run %q: %w", runID, err)
+	}
+
+	results, err := collectPolicyResults(ctx, svc, r)
+	if err != nil {
+		return err
+	}
+
+	if viper.GetBool("json") {
+		if results == nil {
+			results = []policyResult{}
+		}
+		return output.PrintJSON(os.Stdout, results)
+	}
+
+	if len(results) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No policies were evaluated for run %s\n", runID)
+		return nil
+	}
+	printPolicyResults(os.Stdout, results)
+	return nil
+}
+
+// collectPolicyResults gathers the Sentinel policy check and OPA policy
+// evaluation results of a run. Runs without policy checks or task stages are
+// skipped without API calls.
+func collectPolicyResults(ctx context.Context, svc client.PolicyService, r *tfe.Run) ([]policyResult, error) {
+	var evaluations []*tfe.PolicyEvaluation
+	if len(r.TaskStages) > 0 {
+		var err error
+		evaluations, err = svc.ListPolicyEvaluations(ctx, r.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list policy evaluations: %w", err)
+		}
+	}
+	return policyResultsFor(ctx, svc, r, evaluations)
+}
+
+// policyResultsFor gathers the Sentinel policy check results of a run and the
+// results of the given OPA policy evaluations of the run.
+func policyResultsFor(ctx context.Context, svc client.PolicyService, r *tfe.Run, evaluations []*tfe.PolicyEvaluation) ([]policyResult, error) {
+	var results []policyResult
+
+	if len(r.PolicyChecks) > 0 {
+		checks, err := svc.ListPolicyChecks(ctx, r.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list policy checks: %w", err)
+		}
+		for _, pc := range checks {
+			results = append(results, sentinelResults(ctx, svc, pc)...)
+		}
+	}
+
+	for _, pe := range evaluations {
+		outcomes, err := svc.ListPolicySetOutcomes(ctx, pe.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list policy set outcomes: %w", err)
+		}
+		results = append(results, opaResults(outcomes)...)
+	}
+
+	return results, nil
+}
+
+// stagePolicyEvaluations returns the OPA policy evaluations of task stages.
+func stagePolicyEvaluations(stages []*tfe.TaskStage) []*tfe.PolicyEvaluation {
+	var evaluations []*tfe.PolicyEvaluation
+	for _, stage := range stages {
+		evaluations = append(evaluations, stage.PolicyEvaluations...)
+	}
+	return evaluations
+}
+
+// sentinelResults returns the per-policy results of a Sentinel policy check,
+// read from its log. Checks that have not finished, whose log read would wait
+// for them, and checks whose log cannot be read are reported as a whole.
+func sentinelResults(ctx context.Context, svc client.PolicyService, pc *tfe.PolicyCheck) []policyResult {
+	var results []policyResult
+	if isPolicyCheckFinished(pc) {
+		if logs, err := svc.ReadPolicyCheckLogs(ctx, pc.ID); err == nil {
+			results = parseSentinelLog(logs)
+		}
+	}
+	if len(results) > 0 {
+		return results
+	}
+	return []policyResult{{
+		PolicySet: "-",
+		Policy:    pc.ID,
+		Kind:      policyKindSentinel,
+		Status:    string(pc.Status),
+	}}
+}
+
+// isPolicyCheckFinished reports whether a Sentinel policy check has evaluated
+// its policies.
+func isPolicyCheckFinished(pc *tfe.PolicyCheck) bool {
+	switch pc.Status {
+	case tfe.PolicyPasses, tfe.PolicySoftFailed, tfe.PolicyHardFailed, tfe.PolicyOverridden, tfe.PolicyErrored:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	sentinelPolicyPattern = regexp.MustCompile(`^## Policy \d+: (.+) \(([a-z-]+)\)$`)
+	sentinelResultPattern = regexp.MustCompile(`^Result: (true|false)$`)
+)
+
+// parseSentinelLog extracts policy results from Sentinel policy check output:
+//
+//	## Policy 1: my-set/restrict-instance-type (soft-mandatory)
+//
+//	Result: false
+//
+//	FALSE - restrict-instance-type.sentinel:10:1 - Rule "main"
+func parseSentinelLog(r io.Reader) []policyResult {
+	var results []policyResult
+	var current *policyResult
+	var out []string
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if current.Status != "passed" {
+			current.Output = strings.TrimSpace(strings.Join(out, "\n"))
+		}
+		results = append(results, *current)
+		current = nil
+		out = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if m := sentinelPolicyPattern.FindStringSubmatch(line); m != nil {
+			flush()
+			set, name := "-", m[1]
+			if i := strings.Index(name, "/"); i >= 0 {
+				set, name = name[:i], name[i+1:]
+			}
+			current = &policyResult{PolicySet: set, Policy: name, Kind: policyKindSentinel, EnforcementLevel: m[2]}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if m := sentinelResultPattern.FindStringSubmatch(line); m != nil && current.Status == "" {
+			current.Status = "failed"
+			if m[1] == "true" {
+				current.Status = "passed"
+			}
+			continue
+		}
+		out = append(out, line)
+	}
+	flush()
+
+	return results
+}
+
+// opaResults flattens OPA policy set outcomes into per-policy results.
+func opaResults(outcomes []*tfe.PolicySetOutcome) []policyResult {
+	var results []policyResult
+	for _, set := range outcomes {
+		if set.Error != "" && len(set.Outcomes) == 0 {
+			results = append(results, policyResult{
+				PolicySet: set.PolicySetName,
+				Policy:    "-",
+				Kind:      policyKindOPA,
+				Status:    "errored",
+				Output:    set.Error,
+			})
+			continue
+		}
+		for _, o := range set.Outcomes {
+			res := policyResult{
+				PolicySet:        set.PolicySetName,
+				Policy:           o.PolicyName,
+				Kind:             policyKindOPA,
+				EnforcementLevel: string(o.EnforcementLevel),
+				Status:           o.Status,
+			}
+			if o.Status != "passed" {
+				prints := make([]string, 0, len(o.Output))
+				for _, p := range o.Output {
+					prints = append(prints, p.Print)
+				}
+				res.Output = strings.Join(prints, "\n")
+				if res.Output == "" {
+					res.Output = o.Description
+				}
+			}
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// printPolicyResults writes a table of policy results followed by the output
+// of each policy that did not pass.
+func printPolicyResults(w io.Writer, results []policyResult) {
+	headers := []string{"POLICY SET", "POLICY", "KIND", "ENFORCEMENT", "RESULT"}
+	rows := make([][]string, 0, len(results))
+	for _, res := range results {
+		level := res.EnforcementLevel
+		if level == "" {
+			level = "-"
+		}
+		rows = append(rows, []string{res.PolicySet, res.Policy, res.Kind, level, res.Status})
+	}
+	output.Print(w, headers, rows)
+
+	for _, res := range results {
+		if res.Output == "" {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n%s/%s (%s):\n", res.PolicySet, res.Policy, res.Status)
+		for _, line := range strings.Split(res.Output, "\n") {
+			_, _ = fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+}
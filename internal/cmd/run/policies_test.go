@@ -0,0 +1,289 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/viper"
+)
+
+const testSentinelLog = `Sentinel Result: false
+
+This result means that one or more Sentinel policies evaluated to false.
+
+2 policies evaluated.
+
+## Policy 1: networking/restrict-ingress (hard-mandatory)
+
+Result: true
+
+TRUE - restrict-ingress.sentinel:12:1 - Rule "main"
+
+## Policy 2: compute/restrict-instance-type (soft-mandatory)
+
+Result: false
+
+FALSE - restrict-instance-type.sentinel:10:1 - Rule "main"
+  Instance type t3.2xlarge is not allowed
+`
+
+type mockRunPoliciesService struct {
+	run         *tfe.Run
+	checks      []*tfe.PolicyCheck
+	checkLogs   string
+	evaluations []*tfe.PolicyEvaluation
+	outcomes    map[string][]*tfe.PolicySetOutcome
+	checksErr   error
+	logReads    int
+}
+
+func (m *mockRunPoliciesService) ListRuns(_ context.Context, _ string, _ *tfe.RunListOptions) (*tfe.RunList, error) {
+	return nil, fmt.Errorf("ListRuns not implemented in mockRunPoliciesService")
+}
+
+func (m *mockRunPoliciesService) ReadRun(_ context.Context, runID string) (*tfe.Run, error) {
+	if m.run == nil || m.run.ID != runID {
+		return nil, fmt.Errorf("run %q not found", runID)
+	}
+	return m.run, nil
+}
+
+func (m *mockRunPoliciesService) ReadRunWithApply(ctx context.Context, runID string) (*tfe.Run, error) {
+	return m.ReadRun(ctx, runID)
+}
+
+func (m *mockRunPoliciesService) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, fmt.Errorf("CreateRun not implemented in mockRunPoliciesService")
+}
+
+func (m *mockRunPoliciesService) ListPolicyChecks(_ context.Context, _ string) ([]*tfe.PolicyCheck, error) {
+	if m.checksErr != nil {
+		return nil, m.checksErr
+	}
+	return m.checks, nil
+}
+
+func (m *mockRunPoliciesService) ReadPolicyCheckLogs(_ context.Context, _ string) (io.Reader, error) {
+	m.logReads++
+	if m.checkLogs == "" {
+		return nil, fmt.Errorf("logs not available")
+	}
+	return strings.NewReader(m.checkLogs), nil
+}
+
+func (m *mockRunPoliciesService) ListPolicyEvaluations(_ context.Context, _ string) ([]*tfe.PolicyEvaluation, error) {
+	return m.evaluations, nil
+}
+
+func (m *mockRunPoliciesService) ListPolicySetOutcomes(_ context.Context, policyEvaluationID string) ([]*tfe.PolicySetOutcome, error) {
+	return m.outcomes[policyEvaluationID], nil
+}
+
+func newTestRunPoliciesMock() *mockRunPoliciesService {
+	return &mockRunPoliciesService{
+		run: &tfe.Run{
+			ID:           "run-abc123",
+			Status:       tfe.RunPolicySoftFailed,
+			CreatedAt:    time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC),
+			PolicyChecks: []*tfe.PolicyCheck{{ID: "polchk-1"}},
+			TaskStages:   []*tfe.TaskStage{{ID: "ts-1"}},
+		},
+		checks:      []*tfe.PolicyCheck{{ID: "polchk-1", Status: tfe.PolicySoftFailed}},
+		checkLogs:   testSentinelLog,
+		evaluations: []*tfe.PolicyEvaluation{{ID: "poleval-1"}},
+		outcomes: map[string][]*tfe.PolicySetOutcome{
+			"poleval-1": {{
+				PolicySetName: "opa-set",
+				Outcomes: []tfe.Outcome{
+					{PolicyName: "require-tags", EnforcementLevel: tfe.EnforcementMandatory, Status: "failed", Output: []tfe.OutcomeOutput{{Print: "missing tag: owner"}}},
+					{PolicyName: "allowed-regions", EnforcementLevel: tfe.EnforcementAdvisory, Status: "passed"},
+				},
+			}},
+		},
+	}
+}
+
+func TestParseSentinelLog(t *testing.T) {
+	results := parseSentinelLog(strings.NewReader(testSentinelLog))
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d: %+v", len(results), results)
+	}
+
+	passed := results[0]
+	if passed.PolicySet != "networking" || passed.Policy != "restrict-ingress" || passed.EnforcementLevel != "hard-mandatory" || passed.Status != "passed" || passed.Output != "" {
+		t.Errorf("unexpected passed result: %+v", passed)
+	}
+
+	failed := results[1]
+	if failed.PolicySet != "compute" || failed.Policy != "restrict-instance-type" || failed.EnforcementLevel != "soft-mandatory" || failed.Status != "failed" {
+		t.Errorf("unexpected failed result: %+v", failed)
+	}
+	want := "FALSE - restrict-instance-type.sentinel:10:1 - Rule \"main\"\n  Instance type t3.2xlarge is not allowed"
+	if failed.Output != want {
+		t.Errorf("unexpected output:\ngot:  %q\nwant: %q", failed.Output, want)
+	}
+}
+
+func TestOPAResults(t *testing.T) {
+	results := opaResults([]*tfe.PolicySetOutcome{
+		{
+			PolicySetName: "opa-set",
+			Outcomes: []tfe.Outcome{
+				{PolicyName: "require-tags", EnforcementLevel: tfe.EnforcementMandatory, Status: "failed", Description: "All resources must be tagged"},
+			},
+		},
+		{PolicySetName: "broken-set", Error: "failed to load policy"},
+	})
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Output != "All resources must be tagged" || results[0].EnforcementLevel != "mandatory" {
+		t.Errorf("unexpected result: %+v", results[0])
+	}
+	if results[1].Status != "errored" || results[1].Output != "failed to load policy" {
+		t.Errorf("unexpected errored result: %+v", results[1])
+	}
+}
+
+func TestRunPolicies_Table(t *testing.T) {
+	viper.Reset()
+
+	out, err := captureStdout(t, func() error {
+		return runRunPolicies(newTestRunPoliciesMock(), "run-abc123")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"POLICY SET",
+		"compute     restrict-instance-type  sentinel  soft-mandatory  failed",
+		"opa-set     require-tags            opa       mandatory       failed",
+		"compute/restrict-instance-type (failed):",
+		"  Instance type t3.2xlarge is not allowed",
+		"opa-set/require-tags (failed):\n  missing tag: owner",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "restrict-ingress (passed):") {
+		t.Errorf("expected no output section for passed policies, got:\n%s", out)
+	}
+}
+
+func TestRunPolicies_JSON(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	out, err := captureStdout(t, func() error {
+		return runRunPolicies(newTestRunPoliciesMock(), "run-abc123")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var results []policyResult
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("failed to parse JSON: %v\noutput: %s", err, out)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	if results[3].Kind != "opa" || results[3].Policy != "allowed-regions" || results[3].Status != "passed" {
+		t.Errorf("unexpected result: %+v", results[3])
+	}
+}
+
+func TestRunPolicies_NoPolicies(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunPoliciesService{run: &tfe.Run{ID: "run-abc123"}, checksErr: fmt.Errorf("should not be called")}
+	out, err := captureStdout(t, func() error {
+		return runRunPolicies(mock, "run-abc123")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "No policies were evaluated for run run-abc123\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestRunPolicies_LogsUnavailable(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	mock := newTestRunPoliciesMock()
+	mock.checkLogs = ""
+	mock.run.TaskStages = nil
+
+	out, err := captureStdout(t, func() error {
+		return runRunPolicies(mock, "run-abc123")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var results []policyResult
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("failed to parse JSON: %v\noutput: %s", err, out)
+	}
+	if len(results) != 1 || results[0].Policy != "polchk-1" || results[0].Status != "soft_failed" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestRunPolicies_PendingCheck(t *testing.T) {
+	for _, status := range []tfe.PolicyStatus{tfe.PolicyPending, tfe.PolicyQueued} {
+		t.Run(string(status), func(t *testing.T) {
+			viper.Reset()
+			viper.Set("json", true)
+
+			mock := newTestRunPoliciesMock()
+			mock.run.Status = tfe.RunPolicyChecking
+			mock.run.TaskStages = nil
+			mock.checks = []*tfe.PolicyCheck{{ID: "polchk-1", Status: status}}
+
+			out, err := captureStdout(t, func() error {
+				return runRunPolicies(mock, "run-abc123")
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if mock.logReads != 0 {
+				t.Errorf("expected the logs of an unfinished policy check not to be read, got %d reads", mock.logReads)
+			}
+
+			var results []policyResult
+			if err := json.Unmarshal([]byte(out), &results); err != nil {
+				t.Fatalf("failed to parse JSON: %v\noutput: %s", err, out)
+			}
+			if len(results) != 1 || results[0].Policy != "polchk-1" || results[0].Status != string(status) {
+				t.Errorf("unexpected results: %+v", results)
+			}
+		})
+	}
+}
+
+func TestRunPolicies_ListError(t *testing.T) {
+	viper.Reset()
+
+	mock := newTestRunPoliciesMock()
+	mock.checksErr = fmt.Errorf("forbidden")
+
+	err := runRunPolicies(mock, "run-abc123")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "failed to list policy checks: forbidden") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
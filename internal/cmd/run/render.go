@@ -0,0 +1,594 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nnstt1/hcpt/internal/diff"
+)
+
+// Output formats for run show.
+const (
+	showFormatTable     = "table"
+	showFormatTerraform = "terraform"
+)
+
+// planDocument is the subset of the plan JSON used by the terraform renderer.
+type planDocument struct {
+	ResourceChanges []planResourceChange  `json:"resource_changes"`
+	OutputChanges   map[string]planChange `json:"output_changes"`
+}
+
+type planResourceChange struct {
+	Address      string     `json:"address"`
+	Mode         string     `json:"mode"`
+	Type         string     `json:"type"`
+	Name         string     `json:"name"`
+	ActionReason string     `json:"action_reason"`
+	Change       planChange `json:"change"`
+}
+
+type planChange struct {
+	Actions         []string        `json:"actions"`
+	Before          interface{}     `json:"before"`
+	After           interface{}     `json:"after"`
+	AfterUnknown    interface{}     `json:"after_unknown"`
+	BeforeSensitive interface{}     `json:"before_sensitive"`
+	AfterSensitive  interface{}     `json:"after_sensitive"`
+	ReplacePaths    [][]interface{} `json:"replace_paths"`
+}
+
+// valueMarks holds the after_unknown and sensitivity structures matching a
+// value. Each is either true (the whole value is marked) or a map/list with
+// the same shape as the value.
+type valueMarks struct {
+	unknown         interface{}
+	beforeSensitive interface{}
+	afterSensitive  interface{}
+}
+
+// child returns the marks of an element, looked up by its key on each side.
+func (m valueMarks) child(beforeKey, afterKey interface{}) valueMarks {
+	return valueMarks{
+		unknown:         childMark(m.unknown, afterKey),
+		beforeSensitive: childMark(m.beforeSensitive, beforeKey),
+		afterSensitive:  childMark(m.afterSensitive, afterKey),
+	}
+}
+
+func childMark(mark, key interface{}) interface{} {
+	if mark == true {
+		return true
+	}
+	switch m := mark.(type) {
+	case map[string]interface{}:
+		if k, ok := key.(string); ok {
+			return m[k]
+		}
+	case []interface{}:
+		if i, ok := key.(int); ok && i >= 0 && i < len(m) {
+			return m[i]
+		}
+	}
+	return nil
+}
+
+// hasMark reports whether any part of a mark structure is true.
+func hasMark(mark interface{}) bool {
+	switch m := mark.(type) {
+	case bool:
+		return m
+	case map[string]interface{}:
+		for _, v := range m {
+			if hasMark(v) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, v := range m {
+			if hasMark(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// planRenderer writes a plan in the format of `terraform show`.
+type planRenderer struct {
+	w            io.Writer
+	replacePaths map[string]bool // JSON-encoded paths that force replacement
+	blocks       bool            // render lists of objects as nested blocks
+}
+
+// renderPlan writes the resource and output changes of a plan JSON document
+// the way `terraform show` displays them, followed by the change summary.
+func renderPlan(w io.Writer, planJSONBytes []byte) error {
+	var plan planDocument
+	if err := json.Unmarshal(planJSONBytes, &plan); err != nil {
+		return fmt.Errorf("failed to parse plan JSON: %w", err)
+	}
+
+	var resources []planResourceChange
+	for _, rc := range plan.ResourceChanges {
+		if changeSymbol(rc.Change.Actions) != "" {
+			resources = append(resources, rc)
+		}
+	}
+
+	outputs := make([]string, 0, len(plan.OutputChanges))
+	for name, oc := range plan.OutputChanges {
+		if changeSymbol(oc.Actions) != "" {
+			outputs = append(outputs, name)
+		}
+	}
+	sort.Strings(outputs)
+
+	if len(resources) == 0 && len(outputs) == 0 {
+		_, _ = fmt.Fprintln(w, "No changes. Your infrastructure matches the configuration.")
+		return nil
+	}
+
+	var add, change, destroy int
+	if len(resources) > 0 {
+		_, _ = fmt.Fprintln(w, "Terraform will perform the following actions:")
+		for _, rc := range resources {
+			_, _ = fmt.Fprintln(w)
+			p := &planRenderer{w: w, replacePaths: make(map[string]bool), blocks: true}
+			for _, path := range rc.Change.ReplacePaths {
+				p.replacePaths[encodePath(path)] = true
+			}
+			p.resource(rc)
+
+			switch changeSymbol(rc.Change.Actions) {
+			case "+":
+				add++
+			case "~":
+				change++
+			case "-":
+				destroy++
+			case "-/+", "+/-":
+				add++
+				destroy++
+			}
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+
+	if len(outputs) > 0 {
+		_, _ = fmt.Fprintln(w, "Changes to Outputs:")
+		p := &planRenderer{w: w}
+		width := 0
+		for _, name := range outputs {
+			width = max(width, len(name))
+		}
+		for _, name := range outputs {
+			oc := plan.OutputChanges[name]
+			marks := valueMarks{unknown: oc.AfterUnknown, beforeSensitive: oc.BeforeSensitive, afterSensitive: oc.AfterSensitive}
+			p.entry(0, padRight(name, width)+" = ", "", oc.Before, oc.After, marks, nil)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+
+	_, _ = fmt.Fprintf(w, "Plan: %d to add, %d to change, %d to destroy.\n", add, change, destroy)
+	return nil
+}
+
+// changeSymbol returns the terraform symbol for a list of planned actions, or
+// an empty string for no-op changes.
+func changeSymbol(actions []string) string {
+	switch strings.Join(actions, ",") {
+	case "create":
+		return "+"
+	case "delete":
+		return "-"
+	case "update":
+		return "~"
+	case "delete,create":
+		return "-/+"
+	case "create,delete":
+		return "+/-"
+	case "read":
+		return "<="
+	default:
+		return ""
+	}
+}
+
+// changeDescription returns the "# addr will be ..." header text.
+func changeDescription(rc planResourceChange) string {
+	switch changeSymbol(rc.Change.Actions) {
+	case "+":
+		return "will be created"
+	case "-":
+		return "will be destroyed"
+	case "~":
+		return "will be updated in-place"
+	case "<=":
+		return "will be read during apply"
+	default:
+		if rc.ActionReason == "replace_because_tainted" {
+			return "is tainted, so must be replaced"
+		}
+		return "must be replaced"
+	}
+}
+
+func (p *planRenderer) resource(rc planResourceChange) {
+	p.comment(-1, fmt.Sprintf("%s %s", rc.Address, changeDescription(rc)))
+
+	kind := "resource"
+	if rc.Mode == "data" {
+		kind = "data"
+	}
+	p.line(0, changeSymbol(rc.Change.Actions), fmt.Sprintf("%s %q %q {", kind, rc.Type, rc.Name))
+
+	before, _ := rc.Change.Before.(map[string]interface{})
+	after, _ := rc.Change.After.(map[string]interface{})
+	marks := valueMarks{unknown: rc.Change.AfterUnknown, beforeSensitive: rc.Change.BeforeSensitive, afterSensitive: rc.Change.AfterSensitive}
+	p.object(1, before, after, marks, nil)
+
+	p.line(0, "", "}")
+}
+
+// object writes the changed entries of an object at the given level followed
+// by a count of the unchanged ones.
+func (p *planRenderer) object(level int, before, after map[string]interface{}, marks valueMarks, path []interface{}) {
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	// Unknown values may be absent from after altogether
+	if unknown, ok := marks.unknown.(map[string]interface{}); ok {
+		for k, v := range unknown {
+			_, inBefore := before[k]
+			_, inAfter := after[k]
+			if v == true && !inBefore && !inAfter {
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	// Align the "=" of attributes that are displayed
+	width := 0
+	for _, k := range keys {
+		if !p.isBlockList(before[k], after[k]) && isChanged(before[k], after[k], marks.child(k, k)) {
+			width = max(width, len(attributeName(k)))
+		}
+	}
+
+	var attrs []string
+	var blocks []string
+	hidden := 0
+	for _, k := range keys {
+		if p.isBlockList(before[k], after[k]) {
+			blocks = append(blocks, k)
+			continue
+		}
+		attrs = append(attrs, k)
+	}
+
+	for _, k := range attrs {
+		label := padRight(attributeName(k), width) + " = "
+		if !p.entry(level, label, "", before[k], after[k], marks.child(k, k), append(path, k)) && before[k] != nil {
+			hidden++
+		}
+	}
+	if hidden > 0 {
+		p.comment(level, fmt.Sprintf("(%d unchanged %s hidden)", hidden, plural(hidden, "attribute")))
+	}
+
+	for _, k := range blocks {
+		p.blockList(level, k, before[k], after[k], marks.child(k, k), append(path, k))
+	}
+}
+
+// entry writes a single value change. label precedes the value ("name = " for
+// attributes, empty for list elements) and suffix follows it. It returns false
+// if the value is unchanged and nothing was written.
+func (p *planRenderer) entry(level int, label, suffix string, before, after interface{}, marks valueMarks, path []interface{}) bool {
+	if !isChanged(before, after, marks) {
+		return false
+	}
+
+	unknown := marks.unknown == true
+	sym := "~"
+	switch {
+	case before == nil:
+		sym = "+"
+	case after == nil && !unknown:
+		sym = "-"
+	}
+	note := ""
+	if p.replacePaths[encodePath(path)] {
+		note = " # forces replacement"
+	}
+
+	if marks.beforeSensitive == true || marks.afterSensitive == true {
+		text := changeText(sym, diff.SensitiveValue, diff.SensitiveValue, unknown)
+		if sym == "~" && !unknown {
+			// Sensitive values are never shown, so neither is their old value
+			text = diff.SensitiveValue
+		}
+		p.line(level, sym, label+text+suffix+note)
+		return true
+	}
+
+	bDoc, bIsDoc := diff.JSONDocument(before)
+	aDoc, aIsDoc := diff.JSONDocument(after)
+	if !unknown && (bIsDoc || aIsDoc) && (bIsDoc || before == nil) && (aIsDoc || after == nil) && !reflect.DeepEqual(bDoc, aDoc) {
+		p.line(level, sym, label+"jsonencode("+note)
+		inner := &planRenderer{w: p.w}
+		inner.entry(level+1, "", "", bDoc, aDoc, valueMarks{}, nil)
+		p.line(level, "", ")"+suffix)
+		return true
+	}
+
+	bMap, bIsMap := before.(map[string]interface{})
+	aMap, aIsMap := after.(map[string]interface{})
+	bList, bIsList := before.([]interface{})
+	aList, aIsList := after.([]interface{})
+	switch {
+	case unknown:
+		// Containers that become unknown are shown without their contents
+		p.line(level, sym, label+changeText(sym, renderScalar(before), "", true)+suffix+note)
+	case (bIsMap || before == nil) && (aIsMap || after == nil) && (bIsMap || aIsMap) && len(bMap)+len(aMap) > 0:
+		p.line(level, sym, label+"{"+note)
+		p.object(level+1, bMap, aMap, marks, path)
+		p.line(level, "", "}"+suffix)
+	case (bIsList || before == nil) && (aIsList || after == nil) && (bIsList || aIsList) && len(bList)+len(aList) > 0:
+		p.line(level, sym, label+"["+note)
+		p.listElements(level+1, bList, aList, marks, path)
+		p.line(level, "", "]"+suffix)
+	case label == "" && sym == "-":
+		// Removed list elements are shown without "-> null"
+		p.line(level, sym, renderScalar(before)+suffix+note)
+	default:
+		p.line(level, sym, label+changeText(sym, renderScalar(before), renderScalar(after), false)+suffix+note)
+	}
+	return true
+}
+
+// listElements writes the changed elements of a list followed by a count of
+// the unchanged ones. Lists of the same length are compared by position;
+// otherwise elements are aligned so that an insertion shows as one addition.
+func (p *planRenderer) listElements(level int, before, after []interface{}, marks valueMarks, path []interface{}) {
+	hidden := 0
+	if len(before) == len(after) {
+		for i := range before {
+			if !p.entry(level, "", ",", before[i], after[i], marks.child(i, i), append(path, i)) {
+				hidden++
+			}
+		}
+	} else {
+		removed, added := diff.AlignLists(before, after)
+		for _, i := range removed {
+			p.entry(level, "", ",", before[i], nil, marks.child(i, nil), append(path, i))
+		}
+		for _, j := range added {
+			p.entry(level, "", ",", nil, after[j], marks.child(nil, j), append(path, j))
+		}
+		hidden = len(before) - len(removed)
+	}
+	if hidden > 0 {
+		p.comment(level, fmt.Sprintf("(%d unchanged %s hidden)", hidden, plural(hidden, "element")))
+	}
+}
+
+// isBlockList reports whether an attribute holds a list of objects, which
+// terraform displays as repeated nested blocks.
+func (p *planRenderer) isBlockList(before, after interface{}) bool {
+	if !p.blocks {
+		return false
+	}
+	n := 0
+	for _, v := range []interface{}{before, after} {
+		if v == nil {
+			continue
+		}
+		list, ok := v.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, elem := range list {
+			if _, ok := elem.(map[string]interface{}); !ok {
+				return false
+			}
+		}
+		n += len(list)
+	}
+	return n > 0
+}
+
+// blockList writes a list of objects as nested "name { ... }" blocks.
+func (p *planRenderer) blockList(level int, name string, before, after interface{}, marks valueMarks, path []interface{}) {
+	bList, _ := before.([]interface{})
+	aList, _ := after.([]interface{})
+
+	block := func(bi, ai int) bool {
+		var b, a map[string]interface{}
+		var bKey, aKey interface{}
+		if bi >= 0 {
+			b, _ = bList[bi].(map[string]interface{})
+			bKey = bi
+		}
+		if ai >= 0 {
+			a, _ = aList[ai].(map[string]interface{})
+			aKey = ai
+		}
+		// Index on the after side when it exists, matching replace_paths
+		idx := bi
+		if ai >= 0 {
+			idx = ai
+		}
+		childMarks := marks.child(bKey, aKey)
+		if !isChanged(mapValue(b), mapValue(a), childMarks) {
+			return false
+		}
+
+		sym := "~"
+		switch {
+		case b == nil:
+			sym = "+"
+		case a == nil:
+			sym = "-"
+		}
+		elemPath := append(append([]interface{}(nil), path...), idx)
+		note := ""
+		if p.replacePaths[encodePath(elemPath)] || p.replacePaths[encodePath(path)] {
+			note = " # forces replacement"
+		}
+		p.line(level, sym, name+" {"+note)
+		p.object(level+1, b, a, childMarks, elemPath)
+		p.line(level, "", "}")
+		return true
+	}
+
+	hidden := 0
+	if len(bList) == len(aList) {
+		for i := range bList {
+			if !block(i, i) {
+				hidden++
+			}
+		}
+	} else {
+		removed, added := diff.AlignLists(bList, aList)
+		for _, i := range removed {
+			block(i, -1)
+		}
+		for _, j := range added {
+			block(-1, j)
+		}
+		hidden = len(bList) - len(removed)
+	}
+	if hidden > 0 {
+		p.comment(level, fmt.Sprintf("(%d unchanged %s hidden)", hidden, plural(hidden, "block")))
+	}
+}
+
+// line writes a line whose change symbol is right-aligned in a three-column
+// gutter, indented four spaces per level. Level 0 is the resource header.
+func (p *planRenderer) line(level int, sym, text string) {
+	_, _ = fmt.Fprintf(p.w, "%s%3s %s\n", strings.Repeat("    ", level), sym, text)
+}
+
+// comment writes a "# ..." line aligned with unchanged entries at the level.
+// Level -1 is the resource description above the header.
+func (p *planRenderer) comment(level int, text string) {
+	if level < 0 {
+		_, _ = fmt.Fprintf(p.w, "  # %s\n", text)
+		return
+	}
+	p.line(level, "", "# "+text)
+}
+
+// isChanged reports whether a value changes, including values that become
+// unknown.
+func isChanged(before, after interface{}, marks valueMarks) bool {
+	if hasMark(marks.unknown) {
+		return true
+	}
+	return !reflect.DeepEqual(before, after)
+}
+
+// changeText returns the value part of a line for the given change symbol.
+func changeText(sym, before, after string, unknown bool) string {
+	if unknown {
+		after = diff.KnownAfterApplyValue
+	}
+	switch sym {
+	case "+":
+		return after
+	case "-":
+		return before + " -> null"
+	default:
+		if unknown && before == "null" {
+			return after
+		}
+		return before + " -> " + after
+	}
+}
+
+// renderScalar formats a value the way terraform displays it.
+func renderScalar(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(val)
+	case float64:
+		if val == float64(int64(val)) {
+			return strconv.FormatInt(int64(val), 10)
+		}
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case map[string]interface{}:
+		if len(val) == 0 {
+			return "{}"
+		}
+		return "{...}"
+	case []interface{}:
+		if len(val) == 0 {
+			return "[]"
+		}
+		return "[...]"
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// attributeName quotes keys that are not valid identifiers, such as map keys
+// containing dots or slashes.
+func attributeName(k string) string {
+	if identifierPattern.MatchString(k) {
+		return k
+	}
+	return strconv.Quote(k)
+}
+
+// encodePath returns a comparable form of a replace_paths entry.
+func encodePath(path []interface{}) string {
+	b, err := json.Marshal(path)
+	if err != nil {
+		return fmt.Sprintf("%v", path)
+	}
+	return string(b)
+}
+
+// mapValue converts a nil map to an untyped nil so that it compares equal to
+// an absent value.
+func mapValue(m map[string]interface{}) interface{} {
+	if m == nil {
+		return nil
+	}
+	return m
+}
+
+func padRight(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,238 @@
+package run
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const terraformPlanJSON = `{
+  "resource_changes": [
+    {
+      "address": "aws_instance.web",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "change": {
+        "actions": ["create"],
+        "before": null,
+        "after": {
+          "ami": "ami-123",
+          "instance_type": "t3.micro",
+          "tags": {"Name": "web"},
+          "ebs_block_device": [{"device_name": "/dev/sdb", "volume_size": 10}],
+          "user_data": null
+        },
+        "after_unknown": {"id": true, "ebs_block_device": [{}], "tags": {}},
+        "before_sensitive": false,
+        "after_sensitive": {"ebs_block_device": [{}], "tags": {}}
+      }
+    },
+    {
+      "address": "aws_security_group.app",
+      "mode": "managed",
+      "type": "aws_security_group",
+      "name": "app",
+      "change": {
+        "actions": ["update"],
+        "before": {
+          "id": "sg-123",
+          "name": "app",
+          "description": "app",
+          "cidr_blocks": ["10.0.0.0/16"],
+          "tags": {"env": "dev", "team": "infra"}
+        },
+        "after": {
+          "id": "sg-123",
+          "name": "app",
+          "description": "app",
+          "cidr_blocks": ["10.0.0.0/16", "10.1.0.0/16"],
+          "tags": {"env": "prod", "team": "infra"}
+        },
+        "after_unknown": {},
+        "before_sensitive": {},
+        "after_sensitive": {}
+      }
+    },
+    {
+      "address": "aws_db_instance.main",
+      "mode": "managed",
+      "type": "aws_db_instance",
+      "name": "main",
+      "change": {
+        "actions": ["delete", "create"],
+        "before": {"id": "db-1", "engine": "postgres", "engine_version": "13", "password": "old-secret", "identifier": "main"},
+        "after": {"engine": "postgres", "engine_version": "15", "password": "new-secret", "identifier": "main"},
+        "after_unknown": {"id": true},
+        "before_sensitive": {"password": true},
+        "after_sensitive": {"password": true},
+        "replace_paths": [["engine_version"]]
+      }
+    },
+    {
+      "address": "aws_s3_bucket.old",
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "old",
+      "change": {
+        "actions": ["delete"],
+        "before": {"bucket": "old-bucket", "force_destroy": false},
+        "after": null,
+        "after_unknown": {},
+        "before_sensitive": {},
+        "after_sensitive": false
+      }
+    },
+    {
+      "address": "aws_iam_policy.p",
+      "mode": "managed",
+      "type": "aws_iam_policy",
+      "name": "p",
+      "change": {
+        "actions": ["no-op"],
+        "before": {"name": "p"},
+        "after": {"name": "p"}
+      }
+    }
+  ],
+  "output_changes": {
+    "url": {"actions": ["create"], "before": null, "after": null, "after_unknown": true, "before_sensitive": false, "after_sensitive": false},
+    "db_password": {"actions": ["update"], "before": "old-secret", "after": "new-secret", "after_unknown": false, "before_sensitive": true, "after_sensitive": true},
+    "region": {"actions": ["no-op"], "before": "us-east-1", "after": "us-east-1"}
+  }
+}`
+
+func TestRenderPlan(t *testing.T) {
+	var buf bytes.Buffer
+	if err := renderPlan(&buf, []byte(terraformPlanJSON)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `Terraform will perform the following actions:
+
+  # aws_instance.web will be created
+  + resource "aws_instance" "web" {
+      + ami           = "ami-123"
+      + id            = (known after apply)
+      + instance_type = "t3.micro"
+      + tags          = {
+          + Name = "web"
+        }
+      + ebs_block_device {
+          + device_name = "/dev/sdb"
+          + volume_size = 10
+        }
+    }
+
+  # aws_security_group.app will be updated in-place
+  ~ resource "aws_security_group" "app" {
+      ~ cidr_blocks = [
+          + "10.1.0.0/16",
+            # (1 unchanged element hidden)
+        ]
+      ~ tags        = {
+          ~ env = "dev" -> "prod"
+            # (1 unchanged attribute hidden)
+        }
+        # (3 unchanged attributes hidden)
+    }
+
+  # aws_db_instance.main must be replaced
+-/+ resource "aws_db_instance" "main" {
+      ~ engine_version = "13" -> "15" # forces replacement
+      ~ id             = "db-1" -> (known after apply)
+      ~ password       = (sensitive value)
+        # (2 unchanged attributes hidden)
+    }
+
+  # aws_s3_bucket.old will be destroyed
+  - resource "aws_s3_bucket" "old" {
+      - bucket        = "old-bucket" -> null
+      - force_destroy = false -> null
+    }
+
+Changes to Outputs:
+  ~ db_password = (sensitive value)
+  + url         = (known after apply)
+
+Plan: 2 to add, 1 to change, 2 to destroy.
+`
+	if buf.String() != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Errorf("sensitive value leaked in output:\n%s", buf.String())
+	}
+}
+
+func TestRenderPlan_JSONDocumentAndBlocks(t *testing.T) {
+	plan := `{
+  "resource_changes": [{
+    "address": "aws_iam_policy.p",
+    "mode": "managed",
+    "type": "aws_iam_policy",
+    "name": "p",
+    "change": {
+      "actions": ["update"],
+      "before": {
+        "policy": "{\"Statement\":[{\"Action\":\"s3:GetObject\",\"Effect\":\"Allow\"}]}",
+        "ingress": [{"port": 80}, {"port": 443}]
+      },
+      "after": {
+        "policy": "{\"Statement\":[{\"Action\":\"s3:GetObject\",\"Effect\":\"Deny\"}]}",
+        "ingress": [{"port": 80}, {"port": 8443}]
+      },
+      "replace_paths": []
+    }
+  }]
+}`
+
+	var buf bytes.Buffer
+	if err := renderPlan(&buf, []byte(plan)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `Terraform will perform the following actions:
+
+  # aws_iam_policy.p will be updated in-place
+  ~ resource "aws_iam_policy" "p" {
+      ~ policy = jsonencode(
+          ~ {
+              ~ Statement = [
+                  ~ {
+                      ~ Effect = "Allow" -> "Deny"
+                        # (1 unchanged attribute hidden)
+                    },
+                ]
+            }
+        )
+      ~ ingress {
+          ~ port = 443 -> 8443
+        }
+        # (1 unchanged block hidden)
+    }
+
+Plan: 0 to add, 1 to change, 0 to destroy.
+`
+	if buf.String() != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestRenderPlan_NoChanges(t *testing.T) {
+	var buf bytes.Buffer
+	plan := `{"resource_changes": [{"address": "a.b", "type": "a", "name": "b", "change": {"actions": ["no-op"]}}]}`
+	if err := renderPlan(&buf, []byte(plan)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "No changes. Your infrastructure matches the configuration.\n" {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestRenderPlan_InvalidJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := renderPlan(&buf, []byte("not json")); err == nil {
+		t.Fatal("expected error")
+	}
+}
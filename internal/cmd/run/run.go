@@ -13,7 +13,18 @@ func NewCmdRun() *cobra.Command {
 
 	cmd.AddCommand(newCmdRunList())
 	cmd.AddCommand(newCmdRunShow())
+	cmd.AddCommand(newCmdRunDiff())
+	cmd.AddCommand(newCmdRunPolicies())
+	cmd.AddCommand(newCmdRunTimeline())
+	cmd.AddCommand(newCmdRunStats())
 	cmd.AddCommand(newCmdRunLogs())
+	cmd.AddCommand(newCmdRunComment())
+	cmd.AddCommand(newCmdRunWait())
+	cmd.AddCommand(newCmdRunCreate())
+	cmd.AddCommand(newCmdRunApply())
+	cmd.AddCommand(newCmdRunDiscard())
+	cmd.AddCommand(newCmdRunCancel())
+	cmd.AddCommand(newCmdRunForceCancel())
 
 	return cmd
 }
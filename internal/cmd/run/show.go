@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,22 +16,33 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/diff"
 	"github.com/nnstt1/hcpt/internal/output"
 )
 
 type runShowJSON struct {
-	ID                   string           `json:"id"`
-	Status               string           `json:"status"`
-	Message              string           `json:"message"`
-	TerraformVersion     string           `json:"terraform_version"`
-	HasChanges           bool             `json:"has_changes"`
-	ResourceAdditions    int              `json:"resource_additions"`
-	ResourceChanges      int              `json:"resource_changes"`
-	ResourceDestructions int              `json:"resource_destructions"`
-	CreatedAt            time.Time        `json:"created_at"`
-	PlannedAt            *time.Time       `json:"planned_at,omitempty"`
-	AppliedAt            *time.Time       `json:"applied_at,omitempty"`
-	Changes              []resourceChange `json:"changes,omitempty"`
+	ID                   string            `json:"id"`
+	Status               string            `json:"status"`
+	Message              string            `json:"message"`
+	TerraformVersion     string            `json:"terraform_version"`
+	HasChanges           bool              `json:"has_changes"`
+	ResourceAdditions    int               `json:"resource_additions"`
+	ResourceChanges      int               `json:"resource_changes"`
+	ResourceDestructions int               `json:"resource_destructions"`
+	CreatedAt            time.Time         `json:"created_at"`
+	PlannedAt            *time.Time        `json:"planned_at,omitempty"`
+	AppliedAt            *time.Time        `json:"applied_at,omitempty"`
+	Changes              []resourceChange  `json:"changes,omitempty"`
+	Policies             []policyResult    `json:"policies,omitempty"`
+	CostEstimate         *costEstimateJSON `json:"cost_estimate,omitempty"`
+	TaskResults          []taskResult      `json:"task_results,omitempty"`
+}
+
+// runDetails holds the sections displayed below the run summary.
+type runDetails struct {
+	ResourceChanges []resourceChange
+	Policies        []policyResult
+	TaskResults     []taskResult
 }
 
 type resourceChange struct {
@@ -40,16 +52,41 @@ type resourceChange struct {
 	Changes map[string]change `json:"changes,omitempty"`
 }
 
+// change is an attribute-level change. Before and After are omitted (null)
+// when the attribute is sensitive.
 type change struct {
-	Before interface{} `json:"before"`
-	After  interface{} `json:"after"`
+	Before          interface{} `json:"before"`
+	After           interface{} `json:"after"`
+	KnownAfterApply bool        `json:"known_after_apply"`
+	// BeforeKnownAfterApply is set by run diff when the value compared
+	// against was itself unknown until apply.
+	BeforeKnownAfterApply bool `json:"before_known_after_apply,omitempty"`
+	Sensitive             bool `json:"sensitive"`
+}
+
+// runShowOptions holds the display options of run show.
+type runShowOptions struct {
+	Watch    bool
+	PlanJSON bool
+	Format   string
+	// FailedTasksOnly limits the run task results to failed mandatory tasks.
+	FailedTasksOnly bool
+	// Events writes status changes as NDJSON while watching.
+	Events bool
+	// Timeout stops watching after the duration when non-zero.
+	Timeout time.Duration
+	// StopOnConfirmation stops watching when the run waits for a user to
+	// confirm it or to override a policy failure.
+	StopOnConfirmation bool
 }
 
-// runShowService combines RunService, WorkspaceService, and PlanService for run details.
+// runShowService combines RunService, WorkspaceService, PlanService, PolicyService, and TaskService for run details.
 type runShowService interface {
 	client.RunService
 	client.WorkspaceService
 	client.PlanService
+	client.PolicyService
+	client.TaskService
 }
 
 type runShowClientFactory func() (runShowService, error)
@@ -66,8 +103,14 @@ func newCmdRunShowWith(clientFn runShowClientFactory) *cobra.Command {
 	var workspaceName string
 	var watch bool
 	var prNumber int
+	var mrIID int
 	var repoFullName string
 	var planJSON bool
+	var format string
+	var failedTasks bool
+	var events bool
+	var timeout time.Duration
+	var stopOnConfirmation bool
 
 	cmd := &cobra.Command{
 		Use:          "show [run-id]",
@@ -89,6 +132,12 @@ func newCmdRunShowWith(clientFn runShowClientFactory) *cobra.Command {
 			if runID != "" && prNumber > 0 {
 				return fmt.Errorf("cannot specify both run-id and --pr")
 			}
+			if runID != "" && mrIID > 0 {
+				return fmt.Errorf("cannot specify both run-id and --mr")
+			}
+			if prNumber > 0 && mrIID > 0 {
+				return fmt.Errorf("cannot specify both --pr and --mr")
+			}
 
 			if prNumber > 0 && repoFullName == "" {
 				// Try to auto-detect repository from Git remote
@@ -98,19 +147,58 @@ func newCmdRunShowWith(clientFn runShowClientFactory) *cobra.Command {
 				}
 				repoFullName = detectedRepo
 			}
+			if mrIID > 0 && repoFullName == "" {
+				// Try to auto-detect project from Git remote
+				detectedProject, err := client.DetectGitLabProject(context.Background())
+				if err != nil {
+					return err
+				}
+				repoFullName = detectedProject
+			}
 
 			if repoFullName != "" && !strings.Contains(repoFullName, "/") {
+				if mrIID > 0 {
+					return fmt.Errorf("--repo must be in format 'group/project'")
+				}
 				return fmt.Errorf("--repo must be in format 'owner/repo'")
 			}
 
-			if runID == "" && prNumber == 0 && workspaceName == "" {
-				return fmt.Errorf("either run-id, --pr, or --workspace/-w is required")
+			if runID == "" && prNumber == 0 && mrIID == 0 && workspaceName == "" {
+				return fmt.Errorf("either run-id, --pr, --mr, or --workspace/-w is required")
 			}
 
 			if watch && planJSON {
 				return fmt.Errorf("--plan-json cannot be used with --watch")
 			}
 
+			if !watch {
+				switch {
+				case events:
+					return fmt.Errorf("--events requires --watch")
+				case timeout != 0:
+					return fmt.Errorf("--timeout requires --watch")
+				case stopOnConfirmation:
+					return fmt.Errorf("--stop-on-confirmation requires --watch")
+				}
+			}
+			if timeout < 0 {
+				return fmt.Errorf("--timeout must not be negative")
+			}
+
+			if format != showFormatTable && format != showFormatTerraform {
+				return fmt.Errorf("invalid --format %q: must be one of table, terraform", format)
+			}
+			if format == showFormatTerraform {
+				switch {
+				case viper.GetBool("json"):
+					return fmt.Errorf("--format terraform cannot be used with --json")
+				case watch:
+					return fmt.Errorf("--format terraform cannot be used with --watch")
+				case planJSON:
+					return fmt.Errorf("--format terraform cannot be used with --plan-json")
+				}
+			}
+
 			svc, err := clientFn()
 			if err != nil {
 				return err
@@ -125,24 +213,38 @@ func newCmdRunShowWith(clientFn runShowClientFactory) *cobra.Command {
 				}
 			}
 
-			return runRunShow(svc, runID, org, workspaceName, watch, planJSON)
+			// If --mr is specified, get run-id from GitLab
+			if mrIID > 0 {
+				runID, err = resolveRunIDFromMR(context.Background(), repoFullName, mrIID, workspaceName)
+				if err != nil {
+					return err
+				}
+			}
+
+			return runRunShow(svc, runID, org, workspaceName, runShowOptions{Watch: watch, PlanJSON: planJSON, Format: format, FailedTasksOnly: failedTasks, Events: events, Timeout: timeout, StopOnConfirmation: stopOnConfirmation})
 		},
 	}
 
 	cmd.Flags().StringVarP(&workspaceName, "workspace", "w", "", "workspace name (get latest run)")
 	cmd.Flags().BoolVarP(&watch, "watch", "W", false, "watch run status until completion")
 	cmd.Flags().IntVarP(&prNumber, "pr", "p", 0, "GitHub pull request number")
-	cmd.Flags().StringVarP(&repoFullName, "repo", "r", "", "GitHub repository (owner/repo)")
+	cmd.Flags().IntVar(&mrIID, "mr", 0, "GitLab merge request IID")
+	cmd.Flags().StringVarP(&repoFullName, "repo", "r", "", "GitHub repository (owner/repo) or GitLab project (group/project)")
 	cmd.Flags().BoolVar(&planJSON, "plan-json", false, "output plan JSON details")
+	cmd.Flags().StringVar(&format, "format", showFormatTable, "output format for plan changes (table, terraform)")
+	cmd.Flags().BoolVar(&failedTasks, "failed-tasks", false, "show only failed mandatory run tasks")
+	cmd.Flags().BoolVar(&events, "events", false, "with --watch, write status changes and a final summary as NDJSON")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "with --watch, stop watching after this duration (e.g. 30m)")
+	cmd.Flags().BoolVar(&stopOnConfirmation, "stop-on-confirmation", false, "with --watch, stop when the run awaits confirmation or a policy override")
 
 	return cmd
 }
 
-func runRunShow(svc runShowService, runID string, org string, workspaceName string, watch bool, planJSON bool) error {
-	return runRunShowWithInterval(svc, runID, org, workspaceName, watch, planJSON, 5*time.Second)
+func runRunShow(svc runShowService, runID string, org string, workspaceName string, opts runShowOptions) error {
+	return runRunShowWithInterval(svc, runID, org, workspaceName, opts, 5*time.Second)
 }
 
-func runRunShowWithInterval(svc runShowService, runID string, org string, workspaceName string, watch bool, planJSON bool, pollInterval time.Duration) error {
+func runRunShowWithInterval(svc runShowService, runID string, org string, workspaceName string, opts runShowOptions, pollInterval time.Duration) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 
@@ -186,33 +288,55 @@ func runRunShowWithInterval(svc runShowService, runID string, org string, worksp
 	}
 
 	// In watch mode
-	if watch {
-		return watchRun(ctx, svc, runID, r, pollInterval)
+	if opts.Watch {
+		return watchRun(ctx, svc, runID, r, opts, pollInterval)
 	}
 
 	// If --plan-json is specified
-	if planJSON {
+	if opts.PlanJSON {
 		// In JSON mode, displayPlanJSON outputs run info as well
-		return displayPlanJSON(ctx, svc, r)
+		return displayPlanJSON(ctx, svc, r, opts)
+	}
+
+	if opts.Format == showFormatTerraform {
+		return displayRunTerraform(ctx, svc, r)
 	}
 
-	// If plan exists, fetch resource changes
-	var resourceChanges []resourceChange
+	return displayRun(r, fetchRunDetails(ctx, svc, r, opts))
+}
+
+// fetchRunDetails fetches the resource changes, policy results, and run task
+// results of a run. Errors are ignored so that the run is displayed without
+// those sections.
+func fetchRunDetails(ctx context.Context, svc runShowService, r *tfe.Run, opts runShowOptions) runDetails {
+	var details runDetails
 	if r.Plan != nil && r.HasChanges {
 		planJSONBytes, err := svc.ReadPlanJSONOutput(ctx, r.Plan.ID)
 		if err == nil {
-			resourceChanges, _ = extractResourceChanges(planJSONBytes)
+			details.ResourceChanges, _ = extractResourceChanges(planJSONBytes)
 		}
-		// Ignore error and display without resource changes
 	}
+	details.Policies, details.TaskResults = fetchRunChecks(ctx, svc, r, opts)
+	return details
+}
 
-	// Call regular displayRun
-	return displayRun(r, resourceChanges)
+// fetchRunChecks fetches the policy results and the run task results of a
+// run, the latter filtered as requested by opts. The task stages are listed
+// once for both the OPA policy evaluations and the run task results. Errors
+// are ignored so that the run is displayed without those sections.
+func fetchRunChecks(ctx context.Context, svc runShowService, r *tfe.Run, opts runShowOptions) ([]policyResult, []taskResult) {
+	stages, _ := listTaskStages(ctx, svc, r)
+	policies, _ := policyResultsFor(ctx, svc, r, stagePolicyEvaluations(stages))
+	tasks := taskResultsFor(stages)
+	if opts.FailedTasksOnly {
+		tasks = failedMandatoryTasks(tasks)
+	}
+	return policies, tasks
 }
 
-func displayRun(r *tfe.Run, resourceChanges []resourceChange) error {
+func displayRun(r *tfe.Run, details runDetails) error {
 	if viper.GetBool("json") {
-		return output.PrintJSON(os.Stdout, toRunShowJSON(r, resourceChanges))
+		return output.PrintJSON(os.Stdout, toRunShowJSON(r, details))
 	}
 
 	var additions, changes, destructions int
@@ -224,7 +348,7 @@ func displayRun(r *tfe.Run, resourceChanges []resourceChange) error {
 
 	hasChanges := strconv.FormatBool(r.HasChanges)
 	planChanges := fmt.Sprintf("+%d ~%d -%d", additions, changes, destructions)
-	if !isTerminalStatus(r.Status) {
+	if !isTerminalStatus(r.Status) && !isPlanFinished(r) {
 		hasChanges = "-"
 		planChanges = "-"
 	}
@@ -251,27 +375,69 @@ func displayRun(r *tfe.Run, resourceChanges []resourceChange) error {
 	output.PrintKeyValue(os.Stdout, pairs)
 
 	// Display resource changes if any
-	if len(resourceChanges) > 0 {
+	if len(details.ResourceChanges) > 0 {
 		_, _ = fmt.Fprintln(os.Stdout, "")
 		_, _ = fmt.Fprintln(os.Stdout, "Resource Changes:")
-		for _, rc := range resourceChanges {
+		for _, rc := range details.ResourceChanges {
 			action := strings.Join(rc.Actions, ", ")
 			_, _ = fmt.Fprintf(os.Stdout, "- %s [%s]\n", rc.Address, action)
 
 			// Display attribute changes
-			if len(rc.Changes) > 0 {
-				for attr, ch := range rc.Changes {
-					beforeStr := formatValue(ch.Before)
-					afterStr := formatValue(ch.After)
-					_, _ = fmt.Fprintf(os.Stdout, "    %s: %s → %s\n", attr, beforeStr, afterStr)
-				}
+			attrs := make([]string, 0, len(rc.Changes))
+			for attr := range rc.Changes {
+				attrs = append(attrs, attr)
+			}
+			sort.Strings(attrs)
+			for _, attr := range attrs {
+				beforeStr, afterStr := formatChange(rc.Changes[attr])
+				_, _ = fmt.Fprintf(os.Stdout, "    %s: %s → %s\n", attr, beforeStr, afterStr)
 			}
 		}
 	}
 
+	if hasCostEstimate(r) {
+		_, _ = fmt.Fprintln(os.Stdout, "")
+		_, _ = fmt.Fprintln(os.Stdout, "Cost Estimate:")
+		output.PrintKeyValue(os.Stdout, costEstimateKeyValues(r.CostEstimate))
+	}
+
+	if len(details.Policies) > 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "")
+		_, _ = fmt.Fprintln(os.Stdout, "Policies:")
+		printPolicyResults(os.Stdout, details.Policies)
+	}
+
+	if len(details.TaskResults) > 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "")
+		_, _ = fmt.Fprintln(os.Stdout, "Run Tasks:")
+		printTaskResults(os.Stdout, details.TaskResults)
+	}
+
 	return nil
 }
 
+// displayRunTerraform prints the plan of a run in the format of `terraform show`.
+func displayRunTerraform(ctx context.Context, svc runShowService, r *tfe.Run) error {
+	if r.Plan == nil {
+		return fmt.Errorf("this run does not have a plan")
+	}
+	if !isPlanFinished(r) {
+		return fmt.Errorf("the plan of run %s has not finished (status: %s)", r.ID, r.Status)
+	}
+
+	planJSONBytes, err := svc.ReadPlanJSONOutput(ctx, r.Plan.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read plan JSON: %w", err)
+	}
+	return renderPlan(os.Stdout, planJSONBytes)
+}
+
+// isPlanFinished reports whether the run's plan has completed, so that its
+// change counts are final even while the run waits for confirmation.
+func isPlanFinished(r *tfe.Run) bool {
+	return r.Plan != nil && r.Plan.Status == tfe.PlanFinished
+}
+
 // extractResourceChanges extracts resource changes from plan JSON, excluding no-op changes.
 func extractResourceChanges(planJSONBytes []byte) ([]resourceChange, error) {
 	var planData struct {
@@ -279,9 +445,12 @@ func extractResourceChanges(planJSONBytes []byte) ([]resourceChange, error) {
 			Address string `json:"address"`
 			Type    string `json:"type"`
 			Change  struct {
-				Actions []string               `json:"actions"`
-				Before  map[string]interface{} `json:"before"`
-				After   map[string]interface{} `json:"after"`
+				Actions         []string               `json:"actions"`
+				Before          map[string]interface{} `json:"before"`
+				After           map[string]interface{} `json:"after"`
+				AfterUnknown    map[string]interface{} `json:"after_unknown"`
+				BeforeSensitive interface{}            `json:"before_sensitive"`
+				AfterSensitive  interface{}            `json:"after_sensitive"`
 			} `json:"change"`
 		} `json:"resource_changes"`
 	}
@@ -297,8 +466,11 @@ func extractResourceChanges(planJSONBytes []byte) ([]resourceChange, error) {
 			continue
 		}
 
-		// Extract attribute changes
-		attrChanges := extractAttributeChanges(rc.Change.Before, rc.Change.After)
+		// Extract attribute changes; a deleted resource has no after values to show
+		var attrChanges map[string]change
+		if rc.Change.After != nil {
+			attrChanges = extractAttributeChanges(rc.Change.Before, rc.Change.After, rc.Change.AfterUnknown, rc.Change.BeforeSensitive, rc.Change.AfterSensitive)
+		}
 
 		changes = append(changes, resourceChange{
 			Address: rc.Address,
@@ -335,6 +507,12 @@ func formatValue(v interface{}) string {
 			return fmt.Sprintf("%d", int64(val))
 		}
 		return fmt.Sprintf("%g", val)
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(b)
 	default:
 		str := fmt.Sprintf("%v", v)
 		if len(str) > 100 {
@@ -345,7 +523,10 @@ func formatValue(v interface{}) string {
 }
 
 // extractAttributeChanges compares before and after to find changed attributes.
-func extractAttributeChanges(before, after map[string]interface{}) map[string]change {
+// Nested maps and lists are flattened into dot-separated paths (e.g. "tags.env",
+// "ingress.0.cidr_blocks.1"). Sensitive values are masked and unknown values
+// are marked as known after apply.
+func extractAttributeChanges(before, after, afterUnknown map[string]interface{}, beforeSensitive, afterSensitive interface{}) map[string]change {
 	changes := make(map[string]change)
 
 	// Attributes to skip (auto-generated or metadata fields)
@@ -364,64 +545,41 @@ func extractAttributeChanges(before, after map[string]interface{}) map[string]ch
 		"etag":                true,
 	}
 
-	// Check all attributes in before and after
-	allKeys := make(map[string]bool)
-	for k := range before {
-		allKeys[k] = true
-	}
-	for k := range after {
-		allKeys[k] = true
-	}
-
-	for key := range allKeys {
+	for _, d := range diff.Compute(before, after, afterUnknown, beforeSensitive, afterSensitive) {
 		// Skip attributes in skip list
-		if skipAttributes[key] {
-			continue
-		}
-
-		beforeVal := before[key]
-		afterVal := after[key]
-
-		// Skip if both are nil
-		if beforeVal == nil && afterVal == nil {
+		top, _, _ := strings.Cut(d.Key, ".")
+		if skipAttributes[top] {
 			continue
 		}
 
-		// Skip if after is nil (computed/unknown value)
-		if afterVal == nil {
-			continue
-		}
-
-		// Skip if values are equal
-		if fmt.Sprintf("%v", beforeVal) == fmt.Sprintf("%v", afterVal) {
-			continue
-		}
-
-		// Skip complex nested objects (maps, arrays) for simplicity
-		if _, ok := beforeVal.(map[string]interface{}); ok {
-			continue
-		}
-		if _, ok := afterVal.(map[string]interface{}); ok {
-			continue
-		}
-		if _, ok := beforeVal.([]interface{}); ok {
-			continue
-		}
-		if _, ok := afterVal.([]interface{}); ok {
-			continue
-		}
-
-		changes[key] = change{
-			Before: beforeVal,
-			After:  afterVal,
+		ch := change{KnownAfterApply: d.KnownAfterApply, Sensitive: d.Sensitive}
+		if !d.Sensitive {
+			ch.Before = d.BeforeRaw
+			ch.After = d.AfterRaw
 		}
+		changes[d.Key] = ch
 	}
 
 	return changes
 }
 
+// formatChange returns the display strings for an attribute change.
+func formatChange(ch change) (string, string) {
+	beforeStr, afterStr := formatValue(ch.Before), formatValue(ch.After)
+	if ch.Sensitive {
+		beforeStr, afterStr = diff.SensitiveValue, diff.SensitiveValue
+	}
+	if ch.BeforeKnownAfterApply {
+		beforeStr = diff.KnownAfterApplyValue
+	}
+	if ch.KnownAfterApply {
+		afterStr = diff.KnownAfterApplyValue
+	}
+	return beforeStr, afterStr
+}
+
 // toRunShowJSON converts a tfe.Run to runShowJSON structure.
-func toRunShowJSON(r *tfe.Run, resourceChanges []resourceChange) runShowJSON {
+func toRunShowJSON(r *tfe.Run, details runDetails) runShowJSON {
 	var additions, changes, destructions int
 	if r.Plan != nil {
 		additions = r.Plan.ResourceAdditions
@@ -439,7 +597,12 @@ func toRunShowJSON(r *tfe.Run, resourceChanges []resourceChange) runShowJSON {
 		ResourceChanges:      changes,
 		ResourceDestructions: destructions,
 		CreatedAt:            r.CreatedAt,
-		Changes:              resourceChanges,
+		Changes:              details.ResourceChanges,
+		Policies:             details.Policies,
+		TaskResults:          details.TaskResults,
+	}
+	if hasCostEstimate(r) {
+		j.CostEstimate = toCostEstimateJSON(r.CostEstimate)
 	}
 	if r.StatusTimestamps != nil {
 		if !r.StatusTimestamps.PlannedAt.IsZero() {
@@ -453,7 +616,7 @@ func toRunShowJSON(r *tfe.Run, resourceChanges []resourceChange) runShowJSON {
 }
 
 // displayPlanJSON outputs the plan JSON details.
-func displayPlanJSON(ctx context.Context, svc runShowService, r *tfe.Run) error {
+func displayPlanJSON(ctx context.Context, svc runShowService, r *tfe.Run, opts runShowOptions) error {
 	if r.Plan == nil {
 		return fmt.Errorf("this run does not have a plan")
 	}
@@ -464,7 +627,9 @@ func displayPlanJSON(ctx context.Context, svc runShowService, r *tfe.Run) error
 	}
 
 	// Extract resource changes
-	resourceChanges, _ := extractResourceChanges(planJSONBytes)
+	details := runDetails{}
+	details.ResourceChanges, _ = extractResourceChanges(planJSONBytes)
+	details.Policies, details.TaskResults = fetchRunChecks(ctx, svc, r, opts)
 
 	// Switch output format based on --json flag
 	if viper.GetBool("json") {
@@ -475,14 +640,14 @@ func displayPlanJSON(ctx context.Context, svc runShowService, r *tfe.Run) error
 		}
 
 		combined := map[string]interface{}{
-			"run":       toRunShowJSON(r, resourceChanges),
+			"run":       toRunShowJSON(r, details),
 			"plan_json": planData,
 		}
 		return output.PrintJSON(os.Stdout, combined)
 	}
 
 	// Table mode: display run info first, then separator and plan JSON
-	if err := displayRun(r, resourceChanges); err != nil {
+	if err := displayRun(r, details); err != nil {
 		return err
 	}
 	_, _ = fmt.Fprintln(os.Stdout, "---")
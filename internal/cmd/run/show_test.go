@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -56,10 +57,38 @@ func (m *mockRunShowService) ReadRunWithApply(_ context.Context, _ string) (*tfe
 	return m.run, nil
 }
 
+func (m *mockRunShowService) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, fmt.Errorf("CreateRun not implemented in mockRunShowService")
+}
+
 func (m *mockRunShowService) ReadPlanJSONOutput(_ context.Context, _ string) ([]byte, error) {
 	return nil, fmt.Errorf("ReadPlanJSONOutput not implemented in mockRunShowService")
 }
 
+func (m *mockRunShowService) ReadPlanLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, fmt.Errorf("ReadPlanLogs not implemented in mockRunShowService")
+}
+
+func (m *mockRunShowService) ListPolicyChecks(_ context.Context, _ string) ([]*tfe.PolicyCheck, error) {
+	return nil, nil
+}
+
+func (m *mockRunShowService) ReadPolicyCheckLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, fmt.Errorf("ReadPolicyCheckLogs not implemented in mockRunShowService")
+}
+
+func (m *mockRunShowService) ListPolicyEvaluations(_ context.Context, _ string) ([]*tfe.PolicyEvaluation, error) {
+	return nil, nil
+}
+
+func (m *mockRunShowService) ListPolicySetOutcomes(_ context.Context, _ string) ([]*tfe.PolicySetOutcome, error) {
+	return nil, nil
+}
+
+func (m *mockRunShowService) ListTaskStages(_ context.Context, _ string) ([]*tfe.TaskStage, error) {
+	return nil, nil
+}
+
 type mockRunShowServiceExtended struct {
 	mockRunShowService
 	planJSON    []byte
@@ -129,7 +158,7 @@ func TestRunShow_Table_Output(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunShow(mock, "run-abc123", "", "", false, false)
+	err := runRunShow(mock, "run-abc123", "", "", runShowOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -175,7 +204,7 @@ func TestRunShow_Table_WithTimestamps(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunShow(mock, "run-abc123", "", "", false, false)
+	err := runRunShow(mock, "run-abc123", "", "", runShowOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -216,7 +245,7 @@ func TestRunShow_Table_NonTerminalStatus(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunShow(mock, "run-planning", "", "", false, false)
+	err := runRunShow(mock, "run-planning", "", "", runShowOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -273,7 +302,7 @@ func TestRunShow_JSON(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunShow(mock, "run-abc123", "", "", false, false)
+	err := runRunShow(mock, "run-abc123", "", "", runShowOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -364,8 +393,8 @@ func TestRunShow_NoArgs(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
-	if !strings.Contains(err.Error(), "either run-id, --pr, or --workspace/-w is required") {
-		t.Errorf("expected 'either run-id, --pr, or --workspace/-w is required' error, got: %v", err)
+	if !strings.Contains(err.Error(), "either run-id, --pr, --mr, or --workspace/-w is required") {
+		t.Errorf("expected 'either run-id, --pr, --mr, or --workspace/-w is required' error, got: %v", err)
 	}
 }
 
@@ -404,7 +433,7 @@ func TestRunShow_WithWorkspace_Table(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunShow(mock, "", "test-org", "production", false, false)
+	err := runRunShow(mock, "", "test-org", "production", runShowOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -454,7 +483,7 @@ func TestRunShow_WithWorkspace_JSON(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunShow(mock, "", "test-org", "production", false, false)
+	err := runRunShow(mock, "", "test-org", "production", runShowOptions{})
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -645,10 +674,38 @@ func (m *mockRunShowServiceWithWatch) ReadRunWithApply(_ context.Context, _ stri
 	return r, nil
 }
 
+func (m *mockRunShowServiceWithWatch) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, fmt.Errorf("CreateRun not implemented in mockRunShowServiceWithWatch")
+}
+
 func (m *mockRunShowServiceWithWatch) ReadPlanJSONOutput(_ context.Context, _ string) ([]byte, error) {
 	return nil, fmt.Errorf("ReadPlanJSONOutput not implemented in mockRunShowServiceWithWatch")
 }
 
+func (m *mockRunShowServiceWithWatch) ReadPlanLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, fmt.Errorf("ReadPlanLogs not implemented in mockRunShowServiceWithWatch")
+}
+
+func (m *mockRunShowServiceWithWatch) ListPolicyChecks(_ context.Context, _ string) ([]*tfe.PolicyCheck, error) {
+	return nil, nil
+}
+
+func (m *mockRunShowServiceWithWatch) ReadPolicyCheckLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, fmt.Errorf("ReadPolicyCheckLogs not implemented in mockRunShowServiceWithWatch")
+}
+
+func (m *mockRunShowServiceWithWatch) ListPolicyEvaluations(_ context.Context, _ string) ([]*tfe.PolicyEvaluation, error) {
+	return nil, nil
+}
+
+func (m *mockRunShowServiceWithWatch) ListPolicySetOutcomes(_ context.Context, _ string) ([]*tfe.PolicySetOutcome, error) {
+	return nil, nil
+}
+
+func (m *mockRunShowServiceWithWatch) ListTaskStages(_ context.Context, _ string) ([]*tfe.TaskStage, error) {
+	return nil, nil
+}
+
 func TestIsTerminalStatus(t *testing.T) {
 	tests := []struct {
 		status   tfe.RunStatus
@@ -738,7 +795,7 @@ func TestRunShow_Watch_StatusChange(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunShowWithInterval(mock, "run-watch123", "", "", true, false, 10*time.Millisecond)
+	err := runRunShowWithInterval(mock, "run-watch123", "", "", runShowOptions{Watch: true}, 10*time.Millisecond)
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -812,7 +869,7 @@ func TestRunShow_Watch_JSON(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunShowWithInterval(mock, "run-watch-json", "", "", true, false, 10*time.Millisecond)
+	err := runRunShowWithInterval(mock, "run-watch-json", "", "", runShowOptions{Watch: true}, 10*time.Millisecond)
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -864,7 +921,7 @@ func TestRunShow_Watch_AlreadyTerminal(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runRunShowWithInterval(mock, "run-terminal", "", "", true, false, 10*time.Millisecond)
+	err := runRunShowWithInterval(mock, "run-terminal", "", "", runShowOptions{Watch: true}, 10*time.Millisecond)
 
 	_ = w.Close()
 	os.Stdout = oldStdout
@@ -945,7 +1002,7 @@ func TestRunShow_Watch_APIError(t *testing.T) {
 		finalRun:   mock.runs[2],
 	}
 
-	err := runRunShowWithInterval(mockWithError, "run-error", "", "", true, false, 10*time.Millisecond)
+	err := runRunShowWithInterval(mockWithError, "run-error", "", "", runShowOptions{Watch: true}, 10*time.Millisecond)
 
 	_ = stdoutW.Close()
 	_ = stderrW.Close()
@@ -1016,10 +1073,38 @@ func (m *mockRunShowServiceWithWatchError) ReadRunWithApply(_ context.Context, _
 	return m.finalRun, nil
 }
 
+func (m *mockRunShowServiceWithWatchError) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, fmt.Errorf("CreateRun not implemented in mockRunShowServiceWithWatchError")
+}
+
 func (m *mockRunShowServiceWithWatchError) ReadPlanJSONOutput(_ context.Context, _ string) ([]byte, error) {
 	return nil, fmt.Errorf("ReadPlanJSONOutput not implemented in mockRunShowServiceWithWatchError")
 }
 
+func (m *mockRunShowServiceWithWatchError) ReadPlanLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, fmt.Errorf("ReadPlanLogs not implemented in mockRunShowServiceWithWatchError")
+}
+
+func (m *mockRunShowServiceWithWatchError) ListPolicyChecks(_ context.Context, _ string) ([]*tfe.PolicyCheck, error) {
+	return nil, nil
+}
+
+func (m *mockRunShowServiceWithWatchError) ReadPolicyCheckLogs(_ context.Context, _ string) (io.Reader, error) {
+	return nil, fmt.Errorf("ReadPolicyCheckLogs not implemented in mockRunShowServiceWithWatchError")
+}
+
+func (m *mockRunShowServiceWithWatchError) ListPolicyEvaluations(_ context.Context, _ string) ([]*tfe.PolicyEvaluation, error) {
+	return nil, nil
+}
+
+func (m *mockRunShowServiceWithWatchError) ListPolicySetOutcomes(_ context.Context, _ string) ([]*tfe.PolicySetOutcome, error) {
+	return nil, nil
+}
+
+func (m *mockRunShowServiceWithWatchError) ListTaskStages(_ context.Context, _ string) ([]*tfe.TaskStage, error) {
+	return nil, nil
+}
+
 func TestRunShow_WithPR_InvalidRepoFormat(t *testing.T) {
 	viper.Reset()
 
@@ -1084,7 +1169,7 @@ func TestRunShow_NoArgsNoPRNoWorkspace(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
-	if !strings.Contains(err.Error(), "either run-id, --pr, or --workspace/-w is required") {
+	if !strings.Contains(err.Error(), "either run-id, --pr, --mr, or --workspace/-w is required") {
 		t.Errorf("expected 'run-id or --pr or --workspace/-w required' error, got: %v", err)
 	}
 }
@@ -1268,3 +1353,776 @@ func TestRunShow_WithWatch_PlanJSON_Error(t *testing.T) {
 		t.Errorf("unexpected error message: %v", err)
 	}
 }
+
+const sensitivePlanJSON = `{
+  "resource_changes": [{
+    "address": "aws_db_instance.main",
+    "type": "aws_db_instance",
+    "change": {
+      "actions": ["update"],
+      "before": {"password": "old-secret", "instance_class": "db.t3.micro", "endpoint": "db.example.com"},
+      "after": {"password": "new-secret", "instance_class": "db.t3.small"},
+      "after_unknown": {"endpoint": true},
+      "before_sensitive": {"password": true},
+      "after_sensitive": {"password": true}
+    }
+  }]
+}`
+
+func TestExtractResourceChanges_SensitiveAndUnknown(t *testing.T) {
+	changes, err := extractResourceChanges([]byte(sensitivePlanJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 resource change, got %d", len(changes))
+	}
+
+	attrs := changes[0].Changes
+	password, ok := attrs["password"]
+	if !ok {
+		t.Fatalf("expected password change, got %+v", attrs)
+	}
+	if !password.Sensitive || password.Before != nil || password.After != nil {
+		t.Errorf("expected masked sensitive change, got %+v", password)
+	}
+
+	endpoint, ok := attrs["endpoint"]
+	if !ok {
+		t.Fatalf("expected endpoint change, got %+v", attrs)
+	}
+	if !endpoint.KnownAfterApply || endpoint.Before != "db.example.com" || endpoint.After != nil {
+		t.Errorf("expected known-after-apply change, got %+v", endpoint)
+	}
+
+	class := attrs["instance_class"]
+	if class.Sensitive || class.KnownAfterApply || class.After != "db.t3.small" {
+		t.Errorf("unexpected instance_class change: %+v", class)
+	}
+}
+
+func TestRunShow_SensitiveMasked(t *testing.T) {
+	for _, jsonMode := range []bool{false, true} {
+		t.Run(fmt.Sprintf("json=%v", jsonMode), func(t *testing.T) {
+			viper.Reset()
+			viper.Set("json", jsonMode)
+
+			mock := &mockRunShowServiceExtended{
+				mockRunShowService: mockRunShowService{
+					run: &tfe.Run{
+						ID:         "run-abc123",
+						Status:     tfe.RunPlannedAndFinished,
+						HasChanges: true,
+						Plan:       &tfe.Plan{ID: "plan-xyz", ResourceChanges: 1},
+						CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				planJSON: []byte(sensitivePlanJSON),
+			}
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runRunShow(mock, "run-abc123", "", "", runShowOptions{})
+
+			_ = w.Close()
+			os.Stdout = oldStdout
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+			got := buf.String()
+
+			for _, secret := range []string{"old-secret", "new-secret"} {
+				if strings.Contains(got, secret) {
+					t.Errorf("sensitive value %q leaked in output:\n%s", secret, got)
+				}
+			}
+
+			want := []string{"password: (sensitive value) → (sensitive value)", "endpoint: db.example.com → (known after apply)"}
+			if jsonMode {
+				want = []string{`"sensitive": true`, `"known_after_apply": true`}
+			}
+			for _, s := range want {
+				if !strings.Contains(got, s) {
+					t.Errorf("expected %q in output, got:\n%s", s, got)
+				}
+			}
+		})
+	}
+}
+
+const nestedPlanJSON = `{
+  "resource_changes": [{
+    "address": "aws_security_group.web",
+    "type": "aws_security_group",
+    "change": {
+      "actions": ["update"],
+      "before": {
+        "tags": {"env": "dev", "team": "infra"},
+        "cidr_blocks": ["10.0.0.0/16", "10.1.0.0/16"],
+        "policy": "{\"Statement\":[{\"Effect\":\"Allow\"}]}"
+      },
+      "after": {
+        "tags": {"env": "prod", "team": "infra"},
+        "cidr_blocks": ["10.0.0.0/16"],
+        "policy": "{\"Statement\":[{\"Effect\":\"Deny\"}]}"
+      },
+      "after_unknown": {}
+    }
+  }]
+}`
+
+func TestExtractResourceChanges_Nested(t *testing.T) {
+	changes, err := extractResourceChanges([]byte(nestedPlanJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 resource change, got %d", len(changes))
+	}
+
+	attrs := changes[0].Changes
+	if len(attrs) != 3 {
+		t.Errorf("expected 3 attribute changes, got %+v", attrs)
+	}
+	if ch := attrs["tags.env"]; ch.Before != "dev" || ch.After != "prod" {
+		t.Errorf("unexpected tags.env change: %+v", ch)
+	}
+	if ch, ok := attrs["cidr_blocks.1"]; !ok || ch.Before != "10.1.0.0/16" || ch.After != nil {
+		t.Errorf("unexpected cidr_blocks.1 change: %+v", ch)
+	}
+	if ch := attrs["policy.Statement.0.Effect"]; ch.Before != "Allow" || ch.After != "Deny" {
+		t.Errorf("unexpected policy change: %+v", ch)
+	}
+}
+
+func TestRunShow_NestedChanges(t *testing.T) {
+	for _, jsonMode := range []bool{false, true} {
+		t.Run(fmt.Sprintf("json=%v", jsonMode), func(t *testing.T) {
+			viper.Reset()
+			viper.Set("json", jsonMode)
+
+			mock := &mockRunShowServiceExtended{
+				mockRunShowService: mockRunShowService{
+					run: &tfe.Run{
+						ID:         "run-abc123",
+						Status:     tfe.RunPlannedAndFinished,
+						HasChanges: true,
+						Plan:       &tfe.Plan{ID: "plan-xyz", ResourceChanges: 1},
+						CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				planJSON: []byte(nestedPlanJSON),
+			}
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runRunShow(mock, "run-abc123", "", "", runShowOptions{})
+
+			_ = w.Close()
+			os.Stdout = oldStdout
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+			got := buf.String()
+
+			want := []string{
+				"tags.env: dev → prod",
+				"cidr_blocks.1: 10.1.0.0/16 → (null)",
+				"policy.Statement.0.Effect: Allow → Deny",
+			}
+			if jsonMode {
+				want = []string{`"tags.env"`, `"cidr_blocks.1"`, `"policy.Statement.0.Effect"`}
+			}
+			for _, s := range want {
+				if !strings.Contains(got, s) {
+					t.Errorf("expected %q in output, got:\n%s", s, got)
+				}
+			}
+			if strings.Contains(got, "tags.team") {
+				t.Errorf("unchanged nested attribute shown in output:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestRunShow_FormatTerraform(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunShowServiceExtended{
+		mockRunShowService: mockRunShowService{
+			run: &tfe.Run{
+				ID:         "run-abc123",
+				Status:     tfe.RunPlannedAndFinished,
+				HasChanges: true,
+				Plan:       &tfe.Plan{ID: "plan-xyz", Status: tfe.PlanFinished, ResourceChanges: 1},
+				CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		planJSON: []byte(nestedPlanJSON),
+	}
+
+	cmd := newCmdRunShowWith(func() (runShowService, error) {
+		return mock, nil
+	})
+	cmd.SetArgs([]string{"run-abc123", "--format", "terraform"})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := cmd.Execute()
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	got := buf.String()
+
+	for _, want := range []string{
+		"# aws_security_group.web will be updated in-place",
+		`- "10.1.0.0/16",`,
+		`~ env = "dev" -> "prod"`,
+		"Plan: 0 to add, 1 to change, 0 to destroy.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Status:") {
+		t.Errorf("expected only the rendered plan, got:\n%s", got)
+	}
+}
+
+func TestRunShow_FormatTerraform_PlanNotFinished(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunShowServiceExtended{
+		mockRunShowService: mockRunShowService{
+			run: &tfe.Run{
+				ID:     "run-abc123",
+				Status: tfe.RunPlanning,
+				Plan:   &tfe.Plan{ID: "plan-xyz", Status: tfe.PlanRunning},
+			},
+		},
+	}
+
+	err := runRunShow(mock, "run-abc123", "", "", runShowOptions{Format: showFormatTerraform})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "has not finished") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunShow_FormatValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		json bool
+		want string
+	}{
+		{"invalid format", []string{"run-abc123", "--format", "yaml"}, false, `invalid --format "yaml"`},
+		{"with json", []string{"run-abc123", "--format", "terraform"}, true, "--format terraform cannot be used with --json"},
+		{"with watch", []string{"run-abc123", "--format", "terraform", "--watch"}, false, "--format terraform cannot be used with --watch"},
+		{"with plan-json", []string{"run-abc123", "--format", "terraform", "--plan-json"}, false, "--format terraform cannot be used with --plan-json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("json", tt.json)
+
+			cmd := newCmdRunShowWith(func() (runShowService, error) {
+				return &mockRunShowService{}, nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+type mockRunShowServiceWithPolicies struct {
+	mockRunShowService
+	policies *mockRunPoliciesService
+
+	stageLists int // number of ListTaskStages calls
+}
+
+func (m *mockRunShowServiceWithPolicies) ListPolicyChecks(ctx context.Context, runID string) ([]*tfe.PolicyCheck, error) {
+	return m.policies.ListPolicyChecks(ctx, runID)
+}
+
+func (m *mockRunShowServiceWithPolicies) ReadPolicyCheckLogs(ctx context.Context, policyCheckID string) (io.Reader, error) {
+	return m.policies.ReadPolicyCheckLogs(ctx, policyCheckID)
+}
+
+func (m *mockRunShowServiceWithPolicies) ListPolicyEvaluations(ctx context.Context, runID string) ([]*tfe.PolicyEvaluation, error) {
+	return m.policies.ListPolicyEvaluations(ctx, runID)
+}
+
+// ListTaskStages returns the policy evaluations in the run's task stage, as
+// run show reads them from the task stages it lists for the run tasks.
+func (m *mockRunShowServiceWithPolicies) ListTaskStages(_ context.Context, _ string) ([]*tfe.TaskStage, error) {
+	m.stageLists++
+	return []*tfe.TaskStage{{ID: "ts-1", PolicyEvaluations: m.policies.evaluations}}, nil
+}
+
+func (m *mockRunShowServiceWithPolicies) ListPolicySetOutcomes(ctx context.Context, policyEvaluationID string) ([]*tfe.PolicySetOutcome, error) {
+	return m.policies.ListPolicySetOutcomes(ctx, policyEvaluationID)
+}
+
+func TestRunShow_Policies(t *testing.T) {
+	for _, jsonMode := range []bool{false, true} {
+		t.Run(fmt.Sprintf("json=%v", jsonMode), func(t *testing.T) {
+			viper.Reset()
+			viper.Set("json", jsonMode)
+
+			policies := newTestRunPoliciesMock()
+			mock := &mockRunShowServiceWithPolicies{
+				mockRunShowService: mockRunShowService{run: policies.run},
+				policies:           policies,
+			}
+
+			out, err := captureStdout(t, func() error {
+				return runRunShow(mock, "run-abc123", "", "", runShowOptions{})
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if mock.stageLists != 1 {
+				t.Errorf("expected task stages to be listed once, got %d", mock.stageLists)
+			}
+
+			want := []string{"Policies:", "restrict-instance-type", "Instance type t3.2xlarge is not allowed"}
+			if jsonMode {
+				want = []string{`"policies": [`, `"policy": "require-tags"`, `"enforcement_level": "soft-mandatory"`}
+			}
+			for _, s := range want {
+				if !strings.Contains(out, s) {
+					t.Errorf("expected %q in output, got:\n%s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRunShow_CostEstimate(t *testing.T) {
+	run := &tfe.Run{
+		ID:        "run-abc123",
+		Status:    tfe.RunPlanned,
+		CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC),
+		CostEstimate: &tfe.CostEstimate{
+			ID:                      "ce-123",
+			Status:                  tfe.CostEstimateFinished,
+			PriorMonthlyCost:        "10",
+			ProposedMonthlyCost:     "8.75",
+			DeltaMonthlyCost:        "-1.25",
+			MatchedResourcesCount:   2,
+			UnmatchedResourcesCount: 1,
+		},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		viper.Reset()
+		got, err := captureStdout(t, func() error {
+			return runRunShow(&mockRunShowService{run: run}, "run-abc123", "", "", runShowOptions{})
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []string{"Cost Estimate:", "Prior Monthly Cost:", "$10.00", "$8.75", "-$1.25", "2 matched, 1 unmatched"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("expected %q in output, got:\n%s", want, got)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("json", true)
+		got, err := captureStdout(t, func() error {
+			return runRunShow(&mockRunShowService{run: run}, "run-abc123", "", "", runShowOptions{})
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []string{`"cost_estimate": {`, `"prior_monthly_cost": "10"`, `"delta_monthly_cost": "-1.25"`, `"unmatched_resources_count": 1`} {
+			if !strings.Contains(got, want) {
+				t.Errorf("expected %q in JSON output, got:\n%s", want, got)
+			}
+		}
+	})
+}
+
+func TestRunShow_NoCostEstimate(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	mock := &mockRunShowService{
+		run: &tfe.Run{ID: "run-abc123", Status: tfe.RunApplied, CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+	}
+	got, err := captureStdout(t, func() error {
+		return runRunShow(mock, "run-abc123", "", "", runShowOptions{})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(got, "cost_estimate") {
+		t.Errorf("expected no cost_estimate in JSON output, got:\n%s", got)
+	}
+}
+
+type mockRunShowServiceWithTasks struct {
+	mockRunShowService
+	tasks *mockTaskService
+}
+
+func (m *mockRunShowServiceWithTasks) ListTaskStages(ctx context.Context, runID string) ([]*tfe.TaskStage, error) {
+	return m.tasks.ListTaskStages(ctx, runID)
+}
+
+func newTestRunShowTasksMock() *mockRunShowServiceWithTasks {
+	return &mockRunShowServiceWithTasks{
+		mockRunShowService: mockRunShowService{run: &tfe.Run{
+			ID:         "run-abc123",
+			Status:     tfe.RunErrored,
+			CreatedAt:  time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC),
+			TaskStages: []*tfe.TaskStage{{ID: "ts-pre"}, {ID: "ts-post"}},
+		}},
+		tasks: &mockTaskService{stages: testTaskStages()},
+	}
+}
+
+func TestRunShow_TaskResults(t *testing.T) {
+	for _, jsonMode := range []bool{false, true} {
+		t.Run(fmt.Sprintf("json=%v", jsonMode), func(t *testing.T) {
+			viper.Reset()
+			viper.Set("json", jsonMode)
+
+			out, err := captureStdout(t, func() error {
+				return runRunShow(newTestRunShowTasksMock(), "run-abc123", "", "", runShowOptions{})
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := []string{"Run Tasks:", "pre-plan", "lint", "security-scan", "2 critical findings", "https://scanner.example.com/results/1", "cost-check"}
+			if jsonMode {
+				want = []string{`"task_results": [`, `"stage": "post-plan"`, `"task": "security-scan"`, `"enforcement_level": "mandatory"`, `"url": "https://scanner.example.com/results/1"`, `"task": "cost-check"`}
+			}
+			for _, s := range want {
+				if !strings.Contains(out, s) {
+					t.Errorf("expected %q in output, got:\n%s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRunShow_FailedTasks(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	cmd := newCmdRunShowWith(func() (runShowService, error) {
+		return newTestRunShowTasksMock(), nil
+	})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"run-abc123", "--failed-tasks"})
+
+	out, err := captureStdout(t, cmd.Execute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"task": "security-scan"`) {
+		t.Errorf("expected failed mandatory task in output, got:\n%s", out)
+	}
+	for _, s := range []string{`"task": "lint"`, `"task": "cost-check"`} {
+		if strings.Contains(out, s) {
+			t.Errorf("expected %q to be filtered out, got:\n%s", s, out)
+		}
+	}
+}
+
+func TestRunShow_Watch_Events(t *testing.T) {
+	viper.Reset()
+
+	created := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	mock := &mockRunShowServiceWithWatch{
+		runs: []*tfe.Run{
+			{ID: "run-watch123", Status: tfe.RunPlanning, CreatedAt: created},
+			{ID: "run-watch123", Status: tfe.RunPlanning, CreatedAt: created},
+			{ID: "run-watch123", Status: tfe.RunPlanning, CreatedAt: created},
+			{ID: "run-watch123", Status: tfe.RunApplying, CreatedAt: created},
+			{
+				ID:         "run-watch123",
+				Status:     tfe.RunApplied,
+				HasChanges: true,
+				CreatedAt:  created,
+				Plan:       &tfe.Plan{ResourceAdditions: 1, ResourceChanges: 2, ResourceDestructions: 3},
+			},
+		},
+	}
+
+	out, err := captureStdout(t, func() error {
+		return runRunShowWithInterval(mock, "run-watch123", "", "", runShowOptions{Watch: true, Events: true}, 10*time.Millisecond)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 3 status events and a summary, got %d lines:\n%s", len(lines), out)
+	}
+
+	var events []watchStatusEvent
+	for _, line := range lines[:3] {
+		var ev watchStatusEvent
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("failed to parse event %q: %v", line, err)
+		}
+		if ev.Type != watchEventStatus || ev.RunID != "run-watch123" || ev.Timestamp.IsZero() {
+			t.Errorf("unexpected event: %+v", ev)
+		}
+		events = append(events, ev)
+	}
+	want := [][2]string{{"", "planning"}, {"planning", "applying"}, {"applying", "applied"}}
+	for i, w := range want {
+		if events[i].PreviousStatus != w[0] || events[i].Status != w[1] {
+			t.Errorf("event %d = %s -> %s, want %s -> %s", i, events[i].PreviousStatus, events[i].Status, w[0], w[1])
+		}
+	}
+	if strings.Contains(lines[0], "previous_status") {
+		t.Errorf("expected no previous_status in the initial event, got %s", lines[0])
+	}
+
+	var summary watchSummaryEvent
+	if err := json.Unmarshal([]byte(lines[3]), &summary); err != nil {
+		t.Fatalf("failed to parse summary %q: %v", lines[3], err)
+	}
+	if summary.Type != watchEventSummary || summary.Status != "applied" || summary.Transitions != 2 ||
+		!summary.HasChanges || summary.ResourceAdditions != 1 || summary.ResourceChanges != 2 || summary.ResourceDestructions != 3 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+func TestRunShow_Watch_Events_AlreadyTerminal(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunShowServiceWithWatch{
+		runs: []*tfe.Run{
+			{ID: "run-done", Status: tfe.RunErrored, CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	out, err := captureStdout(t, func() error {
+		return runRunShowWithInterval(mock, "run-done", "", "", runShowOptions{Watch: true, Events: true}, 10*time.Millisecond)
+	})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitCodeErrored {
+		t.Fatalf("expected exit code %d, got %v", exitCodeErrored, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a status event and a summary, got:\n%s", out)
+	}
+	if !strings.Contains(lines[0], `"status":"errored"`) || !strings.Contains(lines[1], `"type":"summary"`) || !strings.Contains(lines[1], `"transitions":0`) {
+		t.Errorf("unexpected events:\n%s", out)
+	}
+}
+
+func TestRunShow_EventsRequiresWatch(t *testing.T) {
+	viper.Reset()
+
+	cmd := newCmdRunShowWith(func() (runShowService, error) {
+		return &mockRunShowService{}, nil
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"run-abc123", "--events"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--events requires --watch") {
+		t.Errorf("expected '--events requires --watch' error, got %v", err)
+	}
+}
+
+func TestRunShow_Watch_Timeout(t *testing.T) {
+	for _, events := range []bool{false, true} {
+		t.Run(fmt.Sprintf("events=%v", events), func(t *testing.T) {
+			viper.Reset()
+
+			mock := &mockRunShowServiceWithWatch{
+				runs: []*tfe.Run{
+					{ID: "run-slow", Status: tfe.RunPlanning, CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+				},
+			}
+
+			_, err := captureStdout(t, func() error {
+				opts := runShowOptions{Watch: true, Events: events, Timeout: 30 * time.Millisecond}
+				return runRunShowWithInterval(mock, "run-slow", "", "", opts, 10*time.Millisecond)
+			})
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) || exitErr.Code != exitCodeTimeout {
+				t.Fatalf("expected exit code %d, got %v", exitCodeTimeout, err)
+			}
+			if !strings.Contains(err.Error(), "timed out after 30ms waiting for run run-slow (status: planning)") {
+				t.Errorf("unexpected error message: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunShow_Watch_StopOnConfirmation(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      *tfe.Run
+		wantCode int
+	}{
+		{
+			name:     "confirmable",
+			run:      &tfe.Run{ID: "run-abc123", Status: tfe.RunCostEstimated, Actions: &tfe.RunActions{IsConfirmable: true}},
+			wantCode: exitCodeNeedsConfirmation,
+		},
+		{
+			name:     "planned without actions",
+			run:      &tfe.Run{ID: "run-abc123", Status: tfe.RunPlanned},
+			wantCode: exitCodeNeedsConfirmation,
+		},
+		{
+			name:     "policy soft failed",
+			run:      &tfe.Run{ID: "run-abc123", Status: tfe.RunPolicySoftFailed},
+			wantCode: exitCodePolicySoftFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			mock := &mockRunShowServiceWithWatch{
+				runs: []*tfe.Run{
+					{ID: "run-abc123", Status: tfe.RunPlanning},
+					tt.run,
+				},
+			}
+
+			out, err := captureStdout(t, func() error {
+				opts := runShowOptions{Watch: true, StopOnConfirmation: true, Timeout: time.Second}
+				return runRunShowWithInterval(mock, "run-abc123", "", "", opts, 10*time.Millisecond)
+			})
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) || exitErr.Code != tt.wantCode {
+				t.Fatalf("expected exit code %d, got %v", tt.wantCode, err)
+			}
+			if !strings.Contains(out, string(tt.run.Status)) {
+				t.Errorf("expected final status in output, got:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestRunShow_Watch_ConfirmableWithoutStop(t *testing.T) {
+	viper.Reset()
+
+	mock := &mockRunShowServiceWithWatch{
+		runs: []*tfe.Run{
+			{ID: "run-abc123", Status: tfe.RunPlanning},
+			{ID: "run-abc123", Status: tfe.RunPlanned, Actions: &tfe.RunActions{IsConfirmable: true}},
+			{ID: "run-abc123", Status: tfe.RunApplied},
+		},
+	}
+
+	_, err := captureStdout(t, func() error {
+		return runRunShowWithInterval(mock, "run-abc123", "", "", runShowOptions{Watch: true, Timeout: time.Second}, 10*time.Millisecond)
+	})
+	if err != nil {
+		t.Fatalf("expected the watch to continue past planned and succeed, got %v", err)
+	}
+}
+
+func TestRunShow_WatchOnlyFlags(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"run-abc123", "--timeout", "5m"}, "--timeout requires --watch"},
+		{[]string{"run-abc123", "--stop-on-confirmation"}, "--stop-on-confirmation requires --watch"},
+		{[]string{"run-abc123", "--watch", "--timeout", "-5m"}, "--timeout must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantErr, func(t *testing.T) {
+			viper.Reset()
+
+			cmd := newCmdRunShowWith(func() (runShowService, error) {
+				return &mockRunShowService{}, nil
+			})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected %q error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunShow_MRValidation(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"run-abc123", "--mr", "7"}, "cannot specify both run-id and --mr"},
+		{[]string{"--pr", "42", "--mr", "7", "--repo", "owner/repo"}, "cannot specify both --pr and --mr"},
+		{[]string{"--mr", "7", "--repo", "project"}, "--repo must be in format 'group/project'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantErr, func(t *testing.T) {
+			viper.Reset()
+
+			cmd := newCmdRunShowWith(func() (runShowService, error) {
+				return &mockRunShowService{}, nil
+			})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected %q error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
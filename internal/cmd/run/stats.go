@@ -0,0 +1,384 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/output"
+)
+
+// Output formats of run stats.
+const (
+	statsFormatTable = "table"
+	statsFormatCSV   = "csv"
+)
+
+// runStatsJSON holds reliability statistics of the runs created in a time
+// window. Rates are fractions between 0 and 1, and durations are in seconds.
+// Statistics without any data to compute them from are null.
+type runStatsJSON struct {
+	Since              time.Time      `json:"since"`
+	Until              time.Time      `json:"until"`
+	TotalRuns          int            `json:"total_runs"`
+	StatusCounts       map[string]int `json:"status_counts"`
+	ApplySuccessRate   *float64       `json:"apply_success_rate"`
+	PlanDuration       *durationStats `json:"plan_duration_seconds"`
+	ApplyDuration      *durationStats `json:"apply_duration_seconds"`
+	MeanTimeToRecovery *float64       `json:"mean_time_to_recovery_seconds"`
+	Recoveries         int            `json:"recoveries"`
+	NoChangeRate       *float64       `json:"no_change_rate"`
+}
+
+type durationStats struct {
+	Median float64 `json:"median"`
+	P95    float64 `json:"p95"`
+}
+
+// runStatsOptions holds the scope and time window of run stats.
+type runStatsOptions struct {
+	Projects      []string
+	Tags          []string
+	AllWorkspaces bool
+	Since         time.Time
+	Until         time.Time
+	Format        string
+}
+
+// runStatsService combines RunService, WorkspaceService, and ExplorerService for run statistics.
+type runStatsService interface {
+	client.RunService
+	client.WorkspaceService
+	client.ExplorerService
+}
+
+type runStatsClientFactory func() (runStatsService, error)
+
+func defaultRunStatsClientFactory() (runStatsService, error) {
+	return client.NewClientWrapper()
+}
+
+func newCmdRunStats() *cobra.Command {
+	return newCmdRunStatsWith(defaultRunStatsClientFactory)
+}
+
+func newCmdRunStatsWith(clientFn runStatsClientFactory) *cobra.Command {
+	var workspaceName string
+	var since string
+	var opts runStatsOptions
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show run statistics for reliability reporting",
+		Long: `Show statistics of the runs created in a time window: run counts per status,
+apply success rate, median and p95 plan and apply durations, mean time to
+recovery, and the share of runs with no changes.
+
+The apply success rate counts runs that started applying. Mean time to
+recovery is measured per workspace, from the first errored run of a streak to
+the next successful apply.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			org := viper.GetString("org")
+			if org == "" {
+				return errOrgRequired
+			}
+
+			orgScope := opts.AllWorkspaces || len(opts.Projects) > 0 || len(opts.Tags) > 0
+			if workspaceName != "" && orgScope {
+				return fmt.Errorf("cannot specify --workspace/-w with --project, --tag, or --all-workspaces")
+			}
+			if workspaceName == "" && !orgScope {
+				return fmt.Errorf("one of --workspace/-w, --project, --tag, or --all-workspaces is required")
+			}
+
+			if opts.Format != statsFormatTable && opts.Format != statsFormatCSV {
+				return fmt.Errorf("invalid --format %q: must be one of table, csv", opts.Format)
+			}
+			if opts.Format == statsFormatCSV && viper.GetBool("json") {
+				return fmt.Errorf("--format csv cannot be used with --json")
+			}
+
+			now := time.Now()
+			t, err := parseSince(since, now)
+			if err != nil {
+				return err
+			}
+			opts.Since, opts.Until = t, now
+			opts.AllWorkspaces = orgScope
+
+			svc, err := clientFn()
+			if err != nil {
+				return err
+			}
+			return runRunStats(svc, org, workspaceName, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&workspaceName, "workspace", "w", "", "workspace name")
+	cmd.Flags().StringArrayVar(&opts.Projects, "project", nil, "include workspaces in this project (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Tags, "tag", nil, "include workspaces with this tag as \"key\" or \"key:value\" (can be repeated)")
+	cmd.Flags().BoolVar(&opts.AllWorkspaces, "all-workspaces", false, "include all workspaces in the organization")
+	cmd.Flags().StringVar(&since, "since", "30d", "time window as a duration (e.g. 30d, 12h) or an RFC 3339 start time")
+	cmd.Flags().StringVar(&opts.Format, "format", statsFormatTable, "output format (table, csv)")
+
+	return cmd
+}
+
+func runRunStats(svc runStatsService, org, workspaceName string, opts runStatsOptions) error {
+	runs, err := collectRuns(context.Background(), svc, org, workspaceName, runListOptions{
+		Since:         opts.Since,
+		AllWorkspaces: opts.AllWorkspaces,
+		Projects:      opts.Projects,
+		Tags:          opts.Tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	stats := computeRunStats(runs, opts.Since, opts.Until)
+
+	if viper.GetBool("json") {
+		return output.PrintJSON(os.Stdout, stats)
+	}
+	if opts.Format == statsFormatCSV {
+		return output.PrintCSV(os.Stdout, []string{"metric", "value"}, statsCSVRows(stats))
+	}
+
+	pairs := []output.KeyValue{
+		{Key: "Period", Value: fmt.Sprintf("%s to %s", stats.Since.Format("2006-01-02 15:04:05"), stats.Until.Format("2006-01-02 15:04:05"))},
+		{Key: "Total Runs", Value: strconv.Itoa(stats.TotalRuns)},
+		{Key: "Apply Success Rate", Value: formatRate(stats.ApplySuccessRate)},
+		{Key: "Plan Duration", Value: formatDurationStats(stats.PlanDuration)},
+		{Key: "Apply Duration", Value: formatDurationStats(stats.ApplyDuration)},
+		{Key: "Mean Time to Recovery", Value: formatMTTR(stats.MeanTimeToRecovery, stats.Recoveries)},
+		{Key: "No-Change Runs", Value: formatRate(stats.NoChangeRate)},
+	}
+	output.PrintKeyValue(os.Stdout, pairs)
+
+	if len(stats.StatusCounts) > 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "")
+		rows := make([][]string, 0, len(stats.StatusCounts))
+		for _, status := range sortedStatuses(stats.StatusCounts) {
+			rows = append(rows, []string{status, strconv.Itoa(stats.StatusCounts[status])})
+		}
+		output.Print(os.Stdout, []string{"STATUS", "COUNT"}, rows)
+	}
+	return nil
+}
+
+// computeRunStats computes the statistics of runs created in [since, until).
+func computeRunStats(runs []workspaceRun, since, until time.Time) runStatsJSON {
+	stats := runStatsJSON{
+		Since:        since,
+		Until:        until,
+		StatusCounts: map[string]int{},
+	}
+
+	var planDurations, applyDurations []float64
+	var applyAttempts, applySuccesses, planned, noChanges int
+	byWorkspace := map[string][]*tfe.Run{}
+	for _, wr := range runs {
+		r := wr.Run
+		stats.TotalRuns++
+		stats.StatusCounts[string(r.Status)]++
+		byWorkspace[wr.Workspace] = append(byWorkspace[wr.Workspace], r)
+
+		ts := r.StatusTimestamps
+		if ts == nil {
+			continue
+		}
+		if d, ok := elapsed(ts.PlanningAt, ts.PlannedAt); ok {
+			planDurations = append(planDurations, d)
+		}
+		if d, ok := elapsed(ts.ApplyingAt, ts.AppliedAt); ok {
+			applyDurations = append(applyDurations, d)
+		}
+		if !ts.ApplyingAt.IsZero() {
+			applyAttempts++
+			if r.Status == tfe.RunApplied {
+				applySuccesses++
+			}
+		}
+		if !ts.PlannedAt.IsZero() {
+			planned++
+			if !r.HasChanges {
+				noChanges++
+			}
+		}
+	}
+
+	stats.ApplySuccessRate = ratio(applySuccesses, applyAttempts)
+	stats.NoChangeRate = ratio(noChanges, planned)
+	stats.PlanDuration = summarizeDurations(planDurations)
+	stats.ApplyDuration = summarizeDurations(applyDurations)
+
+	var recoveryTimes []float64
+	for _, wsRuns := range byWorkspace {
+		recoveryTimes = append(recoveryTimes, recoveryDurations(wsRuns)...)
+	}
+	stats.Recoveries = len(recoveryTimes)
+	if len(recoveryTimes) > 0 {
+		var sum float64
+		for _, d := range recoveryTimes {
+			sum += d
+		}
+		mean := sum / float64(len(recoveryTimes))
+		stats.MeanTimeToRecovery = &mean
+	}
+	return stats
+}
+
+// recoveryDurations returns, in seconds, the time from the first errored run
+// of each failure streak in a workspace to the next successful apply.
+func recoveryDurations(runs []*tfe.Run) []float64 {
+	sorted := sortedByCreation(runs)
+
+	var durations []float64
+	var failedAt time.Time
+	for _, r := range sorted {
+		switch r.Status {
+		case tfe.RunErrored:
+			if failedAt.IsZero() {
+				failedAt = r.CreatedAt
+				if r.StatusTimestamps != nil && !r.StatusTimestamps.ErroredAt.IsZero() {
+					failedAt = r.StatusTimestamps.ErroredAt
+				}
+			}
+		case tfe.RunApplied:
+			if failedAt.IsZero() || r.StatusTimestamps == nil {
+				continue
+			}
+			if d, ok := elapsed(failedAt, r.StatusTimestamps.AppliedAt); ok {
+				durations = append(durations, d)
+			}
+			failedAt = time.Time{}
+		}
+	}
+	return durations
+}
+
+func sortedByCreation(runs []*tfe.Run) []*tfe.Run {
+	sorted := make([]*tfe.Run, len(runs))
+	copy(sorted, runs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+	return sorted
+}
+
+// elapsed returns the seconds from start to end if both are set.
+func elapsed(start, end time.Time) (float64, bool) {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0, false
+	}
+	return end.Sub(start).Seconds(), true
+}
+
+func ratio(n, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	r := float64(n) / float64(total)
+	return &r
+}
+
+// summarizeDurations returns the median and p95 of durations using the
+// nearest-rank method.
+func summarizeDurations(durations []float64) *durationStats {
+	if len(durations) == 0 {
+		return nil
+	}
+	sorted := make([]float64, len(durations))
+	copy(sorted, durations)
+	sort.Float64s(sorted)
+	return &durationStats{
+		Median: percentile(sorted, 50),
+		P95:    percentile(sorted, 95),
+	}
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+func sortedStatuses(counts map[string]int) []string {
+	statuses := make([]string, 0, len(counts))
+	for s := range counts {
+		statuses = append(statuses, s)
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+func statsCSVRows(stats runStatsJSON) [][]string {
+	optional := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	}
+	durations := func(d *durationStats) (string, string) {
+		if d == nil {
+			return "", ""
+		}
+		return optional(&d.Median), optional(&d.P95)
+	}
+
+	rows := [][]string{
+		{"since", stats.Since.Format(time.RFC3339)},
+		{"until", stats.Until.Format(time.RFC3339)},
+		{"total_runs", strconv.Itoa(stats.TotalRuns)},
+	}
+	for _, status := range sortedStatuses(stats.StatusCounts) {
+		rows = append(rows, []string{"status_" + status, strconv.Itoa(stats.StatusCounts[status])})
+	}
+	planMedian, planP95 := durations(stats.PlanDuration)
+	applyMedian, applyP95 := durations(stats.ApplyDuration)
+	rows = append(rows,
+		[]string{"apply_success_rate", optional(stats.ApplySuccessRate)},
+		[]string{"plan_duration_median_seconds", planMedian},
+		[]string{"plan_duration_p95_seconds", planP95},
+		[]string{"apply_duration_median_seconds", applyMedian},
+		[]string{"apply_duration_p95_seconds", applyP95},
+		[]string{"mean_time_to_recovery_seconds", optional(stats.MeanTimeToRecovery)},
+		[]string{"recoveries", strconv.Itoa(stats.Recoveries)},
+		[]string{"no_change_rate", optional(stats.NoChangeRate)},
+	)
+	return rows
+}
+
+func formatRate(r *float64) string {
+	if r == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", *r*100)
+}
+
+func formatDurationStats(d *durationStats) string {
+	if d == nil {
+		return "-"
+	}
+	return fmt.Sprintf("median %s, p95 %s", formatSeconds(d.Median), formatSeconds(d.P95))
+}
+
+func formatMTTR(mttr *float64, recoveries int) string {
+	if mttr == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%d recoveries)", formatSeconds(*mttr), recoveries)
+}
+
+func formatSeconds(s float64) string {
+	return formatDuration(time.Duration(s * float64(time.Second)))
+}
@@ -0,0 +1,272 @@
+package run
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+)
+
+var statsBase = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// statsRun returns a run created at statsBase+offset that planned for plan
+// and, if apply is non-zero, applied for apply.
+func statsRun(id string, status tfe.RunStatus, offset, plan, apply time.Duration, hasChanges bool) *tfe.Run {
+	created := statsBase.Add(offset)
+	ts := &tfe.RunStatusTimestamps{
+		PlanningAt: created,
+		PlannedAt:  created.Add(plan),
+	}
+	if apply > 0 {
+		ts.ApplyingAt = ts.PlannedAt.Add(time.Minute)
+		if status == tfe.RunApplied {
+			ts.AppliedAt = ts.ApplyingAt.Add(apply)
+		} else {
+			ts.ErroredAt = ts.ApplyingAt.Add(apply)
+		}
+	}
+	return &tfe.Run{ID: id, Status: status, CreatedAt: created, HasChanges: hasChanges, StatusTimestamps: ts}
+}
+
+// testStatsRuns returns runs of two workspaces. In prod, run-2 and run-3
+// error and run-4 recovers; in dev, run-6 errors and run-7 recovers.
+func testStatsRuns() []workspaceRun {
+	return []workspaceRun{
+		{Workspace: "prod", Run: statsRun("run-1", tfe.RunApplied, 0, time.Minute, 2*time.Minute, true)},
+		{Workspace: "prod", Run: statsRun("run-2", tfe.RunErrored, time.Hour, 2*time.Minute, time.Minute, true)},
+		{Workspace: "prod", Run: statsRun("run-3", tfe.RunErrored, 2*time.Hour, 3*time.Minute, 0, true)},
+		{Workspace: "prod", Run: statsRun("run-4", tfe.RunApplied, 3*time.Hour, 4*time.Minute, 4*time.Minute, true)},
+		{Workspace: "prod", Run: statsRun("run-5", tfe.RunPlannedAndFinished, 4*time.Hour, 5*time.Minute, 0, false)},
+		{Workspace: "dev", Run: statsRun("run-6", tfe.RunErrored, 0, 6*time.Minute, 0, true)},
+		{Workspace: "dev", Run: statsRun("run-7", tfe.RunApplied, 30*time.Minute, 10*time.Minute, 6*time.Minute, true)},
+	}
+}
+
+func TestComputeRunStats(t *testing.T) {
+	stats := computeRunStats(testStatsRuns(), statsBase, statsBase.Add(24*time.Hour))
+
+	if stats.TotalRuns != 7 {
+		t.Errorf("TotalRuns = %d, want 7", stats.TotalRuns)
+	}
+	if stats.StatusCounts["applied"] != 3 || stats.StatusCounts["errored"] != 3 || stats.StatusCounts["planned_and_finished"] != 1 {
+		t.Errorf("unexpected status counts: %v", stats.StatusCounts)
+	}
+
+	// run-1, run-2, run-4 and run-7 started applying; run-2 errored.
+	if stats.ApplySuccessRate == nil || *stats.ApplySuccessRate != 0.75 {
+		t.Errorf("ApplySuccessRate = %v, want 0.75", stats.ApplySuccessRate)
+	}
+	// Plan durations 1..6, 10 minutes: median 4m, p95 10m.
+	if stats.PlanDuration == nil || stats.PlanDuration.Median != 240 || stats.PlanDuration.P95 != 600 {
+		t.Errorf("PlanDuration = %+v, want median 240 p95 600", stats.PlanDuration)
+	}
+	// Apply durations 2, 4, 6 minutes.
+	if stats.ApplyDuration == nil || stats.ApplyDuration.Median != 240 || stats.ApplyDuration.P95 != 360 {
+		t.Errorf("ApplyDuration = %+v, want median 240 p95 360", stats.ApplyDuration)
+	}
+	// prod: run-2 errored at 1h+2m+1m+1m, run-4 applied at 3h+4m+1m+4m -> 7500s.
+	// dev: run-6 has no errored timestamp, so its creation time 0 is used;
+	// run-7 applied at 30m+10m+1m+6m -> 2820s.
+	if stats.Recoveries != 2 {
+		t.Errorf("Recoveries = %d, want 2", stats.Recoveries)
+	}
+	if stats.MeanTimeToRecovery == nil || *stats.MeanTimeToRecovery != (7500+2820)/2 {
+		t.Errorf("MeanTimeToRecovery = %v, want %d", stats.MeanTimeToRecovery, (7500+2820)/2)
+	}
+	// run-5 is the only planned run without changes.
+	if stats.NoChangeRate == nil || *stats.NoChangeRate != 1.0/7 {
+		t.Errorf("NoChangeRate = %v, want 1/7", stats.NoChangeRate)
+	}
+}
+
+func TestComputeRunStats_Empty(t *testing.T) {
+	stats := computeRunStats(nil, statsBase, statsBase.Add(time.Hour))
+
+	if stats.TotalRuns != 0 || stats.ApplySuccessRate != nil || stats.PlanDuration != nil || stats.ApplyDuration != nil || stats.MeanTimeToRecovery != nil || stats.NoChangeRate != nil {
+		t.Errorf("expected empty statistics, got %+v", stats)
+	}
+}
+
+func TestRecoveryDurations_UnsortedAndUnrecovered(t *testing.T) {
+	runs := []*tfe.Run{
+		statsRun("run-3", tfe.RunErrored, 2*time.Hour, time.Minute, 0, true),
+		statsRun("run-1", tfe.RunApplied, 0, time.Minute, time.Minute, true),
+		statsRun("run-2", tfe.RunApplied, time.Hour, time.Minute, time.Minute, true),
+	}
+	if got := recoveryDurations(runs); len(got) != 0 {
+		t.Errorf("expected no recoveries, got %v", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{50, 5},
+		{95, 10},
+		{0, 1},
+	}
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func newTestRunStatsMock() *mockRunListService {
+	runs := testStatsRuns()
+	mock := &mockRunListService{
+		workspace:     &tfe.Workspace{ID: "ws-prod", Name: "prod"},
+		workspaceRuns: map[string][]*tfe.Run{},
+	}
+	for _, wr := range runs {
+		id := "ws-" + wr.Workspace
+		mock.workspaceRuns[id] = append([]*tfe.Run{wr.Run}, mock.workspaceRuns[id]...)
+	}
+	mock.explorer = []client.ExplorerWorkspace{
+		{WorkspaceName: "prod", WorkspaceID: "ws-prod", ProjectName: "core"},
+		{WorkspaceName: "dev", WorkspaceID: "ws-dev", ProjectName: "sandbox"},
+	}
+	return mock
+}
+
+func TestRunStats_Table(t *testing.T) {
+	viper.Reset()
+
+	out, err := captureStdout(t, func() error {
+		return runRunStats(newTestRunStatsMock(), "test-org", "", runStatsOptions{
+			AllWorkspaces: true,
+			Since:         statsBase,
+			Until:         statsBase.Add(24 * time.Hour),
+			Format:        statsFormatTable,
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Period:", "2024-03-01 00:00:00 to 2024-03-02 00:00:00",
+		"Total Runs:", "7",
+		"Apply Success Rate:", "75.0%",
+		"Plan Duration:", "median 4m0s, p95 10m0s",
+		"Mean Time to Recovery:", "1h26m0s (2 recoveries)",
+		"No-Change Runs:", "14.3%",
+		"STATUS", "COUNT", "planned_and_finished",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunStats_Workspace(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	out, err := captureStdout(t, func() error {
+		return runRunStats(newTestRunStatsMock(), "test-org", "prod", runStatsOptions{Since: statsBase, Until: statsBase.Add(24 * time.Hour), Format: statsFormatTable})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`"total_runs": 5`, `"apply_success_rate": 0.6666666666666666`, `"recoveries": 1`, `"mean_time_to_recovery_seconds": 7500`, `"plan_duration_seconds": {`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in JSON output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunStats_Project(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	out, err := captureStdout(t, func() error {
+		return runRunStats(newTestRunStatsMock(), "test-org", "", runStatsOptions{
+			AllWorkspaces: true,
+			Projects:      []string{"sandbox"},
+			Since:         statsBase,
+			Until:         statsBase.Add(24 * time.Hour),
+			Format:        statsFormatTable,
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"total_runs": 2`) {
+		t.Errorf("expected only the runs of the sandbox project, got:\n%s", out)
+	}
+}
+
+func TestRunStats_CSV(t *testing.T) {
+	viper.Reset()
+
+	out, err := captureStdout(t, func() error {
+		return runRunStats(&mockRunListService{
+			workspace:     &tfe.Workspace{ID: "ws-prod", Name: "prod"},
+			workspaceRuns: map[string][]*tfe.Run{"ws-prod": {statsRun("run-1", tfe.RunPlannedAndFinished, 0, time.Minute, 0, false)}},
+		}, "test-org", "prod", runStatsOptions{Since: statsBase, Until: statsBase.Add(24 * time.Hour), Format: statsFormatCSV})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `metric,value
+since,2024-03-01T00:00:00Z
+until,2024-03-02T00:00:00Z
+total_runs,1
+status_planned_and_finished,1
+apply_success_rate,
+plan_duration_median_seconds,60
+plan_duration_p95_seconds,60
+apply_duration_median_seconds,
+apply_duration_p95_seconds,
+mean_time_to_recovery_seconds,
+recoveries,0
+no_change_rate,1
+`
+	if out != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, out)
+	}
+}
+
+func TestRunStats_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		json bool
+		want string
+	}{
+		{"no scope", nil, false, "one of --workspace/-w, --project, --tag, or --all-workspaces is required"},
+		{"workspace and project", []string{"-w", "prod", "--project", "core"}, false, "cannot specify --workspace/-w with --project, --tag, or --all-workspaces"},
+		{"invalid format", []string{"-w", "prod", "--format", "xml"}, false, `invalid --format "xml": must be one of table, csv`},
+		{"csv with json", []string{"-w", "prod", "--format", "csv"}, true, "--format csv cannot be used with --json"},
+		{"invalid since", []string{"-w", "prod", "--since", "last-month"}, false, `invalid --since "last-month"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("org", "test-org")
+			viper.Set("json", tt.json)
+
+			cmd := newCmdRunStatsWith(func() (runStatsService, error) {
+				return newTestRunStatsMock(), nil
+			})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
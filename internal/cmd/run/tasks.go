@@ -0,0 +1,93 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+
+	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/output"
+)
+
+// taskResult is the outcome of a run task in one of the run's task stages.
+type taskResult struct {
+	Stage            string `json:"stage"`
+	Task             string `json:"task"`
+	EnforcementLevel string `json:"enforcement_level"`
+	Status           string `json:"status"`
+	Message          string `json:"message,omitempty"`
+	URL              string `json:"url,omitempty"`
+}
+
+// listTaskStages lists the task stages of a run, which carry both its run task
+// results and its OPA policy evaluations. Runs without task stages are skipped
+// without API calls.
+func listTaskStages(ctx context.Context, svc client.TaskService, r *tfe.Run) ([]*tfe.TaskStage, error) {
+	if len(r.TaskStages) == 0 {
+		return nil, nil
+	}
+	stages, err := svc.ListTaskStages(ctx, r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task stages: %w", err)
+	}
+	return stages, nil
+}
+
+// taskResultsFor returns the run task results of task stages in stage order.
+func taskResultsFor(stages []*tfe.TaskStage) []taskResult {
+	var results []taskResult
+	for _, stage := range stages {
+		for _, tr := range stage.TaskResults {
+			results = append(results, taskResult{
+				Stage:            formatStage(stage.Stage),
+				Task:             tr.TaskName,
+				EnforcementLevel: string(tr.WorkspaceTaskEnforcementLevel),
+				Status:           string(tr.Status),
+				Message:          tr.Message,
+				URL:              tr.URL,
+			})
+		}
+	}
+	return results
+}
+
+// failedMandatoryTasks returns the results of mandatory tasks that did not
+// pass. These are the tasks that block a run.
+func failedMandatoryTasks(results []taskResult) []taskResult {
+	var failed []taskResult
+	for _, res := range results {
+		if res.EnforcementLevel != string(tfe.Mandatory) {
+			continue
+		}
+		switch tfe.TaskResultStatus(res.Status) {
+		case tfe.TaskFailed, tfe.TaskErrored, tfe.TaskUnreachable:
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// formatStage formats a task stage such as "pre_plan" as "pre-plan".
+func formatStage(stage tfe.Stage) string {
+	return strings.ReplaceAll(string(stage), "_", "-")
+}
+
+// printTaskResults writes a table of run task results.
+func printTaskResults(w io.Writer, results []taskResult) {
+	headers := []string{"STAGE", "TASK", "ENFORCEMENT", "STATUS", "MESSAGE", "URL"}
+	rows := make([][]string, 0, len(results))
+	for _, res := range results {
+		message, url := res.Message, res.URL
+		if message == "" {
+			message = "-"
+		}
+		if url == "" {
+			url = "-"
+		}
+		rows = append(rows, []string{res.Stage, res.Task, res.EnforcementLevel, res.Status, truncate(message, 50), url})
+	}
+	output.Print(w, headers, rows)
+}
@@ -0,0 +1,154 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+type mockTaskService struct {
+	stages []*tfe.TaskStage
+	err    error
+	calls  int
+}
+
+func (m *mockTaskService) ListTaskStages(_ context.Context, _ string) ([]*tfe.TaskStage, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.stages, nil
+}
+
+// testTaskStages returns task stages with a passed advisory task, a failed
+// mandatory task, and a failed advisory task.
+func testTaskStages() []*tfe.TaskStage {
+	return []*tfe.TaskStage{
+		{
+			ID:    "ts-pre",
+			Stage: tfe.PrePlan,
+			TaskResults: []*tfe.TaskResult{
+				{TaskName: "lint", Status: tfe.TaskPassed, WorkspaceTaskEnforcementLevel: tfe.Advisory, Message: "ok"},
+			},
+		},
+		{
+			ID:    "ts-post",
+			Stage: tfe.PostPlan,
+			TaskResults: []*tfe.TaskResult{
+				{
+					TaskName:                      "security-scan",
+					Status:                        tfe.TaskFailed,
+					WorkspaceTaskEnforcementLevel: tfe.Mandatory,
+					Message:                       "2 critical findings",
+					URL:                           "https://scanner.example.com/results/1",
+				},
+				{TaskName: "cost-check", Status: tfe.TaskFailed, WorkspaceTaskEnforcementLevel: tfe.Advisory},
+			},
+		},
+	}
+}
+
+func TestTaskResultsFor(t *testing.T) {
+	results := taskResultsFor(testTaskStages())
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d: %v", len(results), results)
+	}
+	want := taskResult{
+		Stage:            "post-plan",
+		Task:             "security-scan",
+		EnforcementLevel: "mandatory",
+		Status:           "failed",
+		Message:          "2 critical findings",
+		URL:              "https://scanner.example.com/results/1",
+	}
+	if results[1] != want {
+		t.Errorf("results[1] = %+v, want %+v", results[1], want)
+	}
+	if results[0].Stage != "pre-plan" {
+		t.Errorf("expected first result in pre-plan stage, got %q", results[0].Stage)
+	}
+}
+
+func TestListTaskStages(t *testing.T) {
+	svc := &mockTaskService{stages: testTaskStages()}
+	r := &tfe.Run{ID: "run-abc123", TaskStages: []*tfe.TaskStage{{ID: "ts-pre"}, {ID: "ts-post"}}}
+
+	stages, err := listTaskStages(context.Background(), svc, r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stages) != 2 || stages[0].ID != "ts-pre" || stages[1].ID != "ts-post" {
+		t.Errorf("unexpected stages: %v", stages)
+	}
+	if svc.calls != 1 {
+		t.Errorf("expected 1 API call, got %d", svc.calls)
+	}
+}
+
+func TestListTaskStages_NoTaskStages(t *testing.T) {
+	svc := &mockTaskService{stages: testTaskStages()}
+
+	stages, err := listTaskStages(context.Background(), svc, &tfe.Run{ID: "run-abc123"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stages != nil {
+		t.Errorf("expected no stages, got %v", stages)
+	}
+	if svc.calls != 0 {
+		t.Errorf("expected no API calls, got %d", svc.calls)
+	}
+}
+
+func TestListTaskStages_Error(t *testing.T) {
+	svc := &mockTaskService{err: errors.New("forbidden")}
+	r := &tfe.Run{ID: "run-abc123", TaskStages: []*tfe.TaskStage{{ID: "ts-pre"}}}
+
+	_, err := listTaskStages(context.Background(), svc, r)
+	if err == nil || !strings.Contains(err.Error(), "failed to list task stages") {
+		t.Errorf("expected list error, got %v", err)
+	}
+}
+
+func TestFailedMandatoryTasks(t *testing.T) {
+	results := []taskResult{
+		{Task: "a", EnforcementLevel: "mandatory", Status: "passed"},
+		{Task: "b", EnforcementLevel: "mandatory", Status: "failed"},
+		{Task: "c", EnforcementLevel: "mandatory", Status: "errored"},
+		{Task: "d", EnforcementLevel: "mandatory", Status: "unreachable"},
+		{Task: "e", EnforcementLevel: "mandatory", Status: "running"},
+		{Task: "f", EnforcementLevel: "advisory", Status: "failed"},
+	}
+
+	got := failedMandatoryTasks(results)
+	var names []string
+	for _, res := range got {
+		names = append(names, res.Task)
+	}
+	if strings.Join(names, ",") != "b,c,d" {
+		t.Errorf("expected b,c,d, got %v", names)
+	}
+}
+
+func TestPrintTaskResults(t *testing.T) {
+	var buf bytes.Buffer
+	printTaskResults(&buf, []taskResult{
+		{Stage: "post-plan", Task: "security-scan", EnforcementLevel: "mandatory", Status: "failed", Message: "2 critical findings", URL: "https://scanner.example.com/results/1"},
+		{Stage: "pre-plan", Task: "lint", EnforcementLevel: "advisory", Status: "passed"},
+	})
+	got := buf.String()
+
+	for _, want := range []string{"STAGE", "TASK", "ENFORCEMENT", "STATUS", "MESSAGE", "URL", "security-scan", "2 critical findings", "https://scanner.example.com/results/1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, got)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if fields := strings.Fields(lines[2]); fields[len(fields)-1] != "-" || fields[len(fields)-2] != "-" {
+		t.Errorf("expected placeholders for empty message and URL, got %q", lines[2])
+	}
+}
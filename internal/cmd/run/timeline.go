@@ -0,0 +1,287 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/output"
+)
+
+type runTimelineJSON struct {
+	RunID       string              `json:"run_id"`
+	Status      string              `json:"status"`
+	Transitions []statusTransition  `json:"transitions"`
+	Phases      []timelinePhase     `json:"phases"`
+	Events      []timelineEventJSON `json:"events"`
+}
+
+// statusTransition is a state the run entered. Duration is the time spent in
+// the state and is omitted for the current state.
+type statusTransition struct {
+	Status          string    `json:"status"`
+	At              time.Time `json:"at"`
+	DurationSeconds *int64    `json:"duration_seconds,omitempty"`
+}
+
+// timelinePhase is a span of the run lifecycle, such as the plan or the wait
+// for confirmation.
+type timelinePhase struct {
+	Name            string    `json:"name"`
+	StartedAt       time.Time `json:"started_at"`
+	EndedAt         time.Time `json:"ended_at"`
+	DurationSeconds int64     `json:"duration_seconds"`
+}
+
+type timelineEventJSON struct {
+	Action      string    `json:"action"`
+	Description string    `json:"description"`
+	At          time.Time `json:"at"`
+	Actor       string    `json:"actor,omitempty"`
+	Comment     string    `json:"comment,omitempty"`
+}
+
+// runTimelineService combines RunService and RunEventService for run timelines.
+type runTimelineService interface {
+	client.RunService
+	client.RunEventService
+}
+
+type runTimelineClientFactory func() (runTimelineService, error)
+
+func defaultRunTimelineClientFactory() (runTimelineService, error) {
+	return client.NewClientWrapper()
+}
+
+func newCmdRunTimeline() *cobra.Command {
+	return newCmdRunTimelineWith(defaultRunTimelineClientFactory)
+}
+
+func newCmdRunTimelineWith(clientFn runTimelineClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timeline <run-id>",
+		Short: "Show the state transitions and phase durations of a run",
+		Long: `Show the state transitions of a run with the time spent in each state,
+the duration of each phase (queued, plan, confirmation wait, apply), and the
+run events, including who confirmed or discarded the run and their comments.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := clientFn()
+			if err != nil {
+				return err
+			}
+			return runRunTimeline(svc, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runRunTimeline(svc runTimelineService, runID string) error {
+	ctx := context.Background()
+
+	r, err := svc.ReadRun(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("failed to read run %q: %w", runID, err)
+	}
+
+	events, err := svc.ListRunEvents(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("failed to list run events: %w", err)
+	}
+
+	timeline := runTimelineJSON{
+		RunID:       r.ID,
+		Status:      string(r.Status),
+		Transitions: statusTransitions(r),
+		Phases:      timelinePhases(r),
+		Events:      toTimelineEvents(events),
+	}
+	if timeline.Phases == nil {
+		timeline.Phases = []timelinePhase{}
+	}
+
+	if viper.GetBool("json") {
+		return output.PrintJSON(os.Stdout, timeline)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Run: %s (%s)\n\n", timeline.RunID, timeline.Status)
+
+	_, _ = fmt.Fprintln(os.Stdout, "Transitions:")
+	rows := make([][]string, 0, len(timeline.Transitions))
+	for _, st := range timeline.Transitions {
+		duration := "-"
+		if st.DurationSeconds != nil {
+			duration = formatDuration(time.Duration(*st.DurationSeconds) * time.Second)
+		}
+		rows = append(rows, []string{st.At.Format("2006-01-02 15:04:05"), st.Status, duration})
+	}
+	output.Print(os.Stdout, []string{"TIME", "STATUS", "DURATION"}, rows)
+
+	if len(timeline.Phases) > 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "")
+		_, _ = fmt.Fprintln(os.Stdout, "Phases:")
+		pairs := make([]output.KeyValue, 0, len(timeline.Phases))
+		for _, p := range timeline.Phases {
+			pairs = append(pairs, output.KeyValue{Key: p.Name, Value: formatDuration(time.Duration(p.DurationSeconds) * time.Second)})
+		}
+		output.PrintKeyValue(os.Stdout, pairs)
+	}
+
+	if len(timeline.Events) > 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "")
+		_, _ = fmt.Fprintln(os.Stdout, "Events:")
+		rows := make([][]string, 0, len(timeline.Events))
+		for _, ev := range timeline.Events {
+			actor, comment := ev.Actor, ev.Comment
+			if actor == "" {
+				actor = "-"
+			}
+			if comment == "" {
+				comment = "-"
+			}
+			rows = append(rows, []string{ev.At.Format("2006-01-02 15:04:05"), ev.Description, actor, truncate(comment, 50)})
+		}
+		output.Print(os.Stdout, []string{"TIME", "EVENT", "ACTOR", "COMMENT"}, rows)
+	}
+
+	return nil
+}
+
+// statusTransitions returns the states a run has entered in chronological
+// order, starting with pending at its creation time.
+func statusTransitions(r *tfe.Run) []statusTransition {
+	transitions := []statusTransition{{Status: string(tfe.RunPending), At: r.CreatedAt}}
+	if ts := r.StatusTimestamps; ts != nil {
+		for _, st := range []struct {
+			status tfe.RunStatus
+			at     time.Time
+		}{
+			{tfe.RunFetching, ts.FetchingAt},
+			{tfe.RunFetchingCompleted, ts.FetchedAt},
+			{tfe.RunPrePlanRunning, ts.PrePlanRunningAt},
+			{tfe.RunPrePlanCompleted, ts.PrePlanCompletedAt},
+			{tfe.RunQueuing, ts.QueuingAt},
+			{tfe.RunPlanQueued, ts.PlanQueuedAt},
+			{tfe.RunPlanning, ts.PlanningAt},
+			{tfe.RunPlanned, ts.PlannedAt},
+			{tfe.RunCostEstimating, ts.CostEstimatingAt},
+			{tfe.RunCostEstimated, ts.CostEstimatedAt},
+			{tfe.RunPolicyChecked, ts.PolicyCheckedAt},
+			{tfe.RunPolicySoftFailed, ts.PolicySoftFailedAt},
+			{tfe.RunPostPlanRunning, ts.PostPlanRunningAt},
+			{tfe.RunPostPlanCompleted, ts.PostPlanCompletedAt},
+			{tfe.RunPlannedAndFinished, ts.PlannedAndFinishedAt},
+			{tfe.RunPlannedAndSaved, ts.PlannedAndSavedAt},
+			{tfe.RunConfirmed, ts.ConfirmedAt},
+			{tfe.RunApplyQueued, ts.ApplyQueuedAt},
+			{tfe.RunApplying, ts.ApplyingAt},
+			{tfe.RunApplied, ts.AppliedAt},
+			{tfe.RunDiscarded, ts.DiscardedAt},
+			{tfe.RunErrored, ts.ErroredAt},
+			{tfe.RunCanceled, ts.CanceledAt},
+			{"force_canceled", ts.ForceCanceledAt},
+		} {
+			if !st.at.IsZero() {
+				transitions = append(transitions, statusTransition{Status: string(st.status), At: st.at})
+			}
+		}
+	}
+
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].At.Before(transitions[j].At)
+	})
+	for i := 0; i < len(transitions)-1; i++ {
+		seconds := int64(transitions[i+1].At.Sub(transitions[i].At).Seconds())
+		transitions[i].DurationSeconds = &seconds
+	}
+	return transitions
+}
+
+// timelinePhases returns the phases of a run that have both started and
+// ended. The queue wait starts when the plan was queued, after the
+// configuration was fetched and the pre-plan tasks ran. The confirmation wait
+// starts when the last post-plan step finished.
+func timelinePhases(r *tfe.Run) []timelinePhase {
+	ts := r.StatusTimestamps
+	if ts == nil {
+		return nil
+	}
+
+	var phases []timelinePhase
+	add := func(name string, start, end time.Time) {
+		if start.IsZero() || end.IsZero() || end.Before(start) {
+			return
+		}
+		phases = append(phases, timelinePhase{
+			Name:            name,
+			StartedAt:       start,
+			EndedAt:         end,
+			DurationSeconds: int64(end.Sub(start).Seconds()),
+		})
+	}
+
+	queuedAt := ts.PlanQueuedAt
+	if queuedAt.IsZero() {
+		queuedAt = ts.QueuingAt
+	}
+	add("Queued", queuedAt, ts.PlanningAt)
+	add("Plan", ts.PlanningAt, ts.PlannedAt)
+
+	if !ts.ConfirmedAt.IsZero() {
+		var planDone time.Time
+		for _, t := range []time.Time{ts.PlannedAt, ts.CostEstimatedAt, ts.PolicyCheckedAt, ts.PolicySoftFailedAt, ts.PostPlanCompletedAt} {
+			if t.After(planDone) && !t.After(ts.ConfirmedAt) {
+				planDone = t
+			}
+		}
+		add("Confirmation Wait", planDone, ts.ConfirmedAt)
+	}
+
+	applyEnd := ts.AppliedAt
+	if applyEnd.IsZero() && ts.ErroredAt.After(ts.ApplyingAt) {
+		applyEnd = ts.ErroredAt
+	}
+	add("Apply", ts.ApplyingAt, applyEnd)
+
+	if isTerminalStatus(r.Status) {
+		transitions := statusTransitions(r)
+		add("Total", r.CreatedAt, transitions[len(transitions)-1].At)
+	}
+	return phases
+}
+
+func toTimelineEvents(events []*tfe.RunEvent) []timelineEventJSON {
+	result := make([]timelineEventJSON, 0, len(events))
+	for _, ev := range events {
+		j := timelineEventJSON{
+			Action:      ev.Action,
+			Description: ev.Description,
+			At:          ev.CreatedAt,
+		}
+		if ev.Actor != nil {
+			j.Actor = ev.Actor.Username
+		}
+		if ev.Comment != nil {
+			j.Comment = ev.Comment.Body
+		}
+		result = append(result, j)
+	}
+	sort.SliceStable(result, func(i, k int) bool {
+		return result[i].At.Before(result[k].At)
+	})
+	return result
+}
+
+// formatDuration formats a duration rounded to seconds, e.g. "1m30s".
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
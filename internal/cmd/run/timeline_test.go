@@ -0,0 +1,273 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/viper"
+)
+
+type mockRunTimelineService struct {
+	run       *tfe.Run
+	events    []*tfe.RunEvent
+	readErr   error
+	eventsErr error
+}
+
+func (m *mockRunTimelineService) ListRuns(_ context.Context, _ string, _ *tfe.RunListOptions) (*tfe.RunList, error) {
+	return nil, nil
+}
+
+func (m *mockRunTimelineService) ReadRun(_ context.Context, _ string) (*tfe.Run, error) {
+	if m.readErr != nil {
+		return nil, m.readErr
+	}
+	return m.run, nil
+}
+
+func (m *mockRunTimelineService) ReadRunWithApply(_ context.Context, _ string) (*tfe.Run, error) {
+	return m.run, nil
+}
+
+func (m *mockRunTimelineService) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, nil
+}
+
+func (m *mockRunTimelineService) ListRunEvents(_ context.Context, _ string) ([]*tfe.RunEvent, error) {
+	if m.eventsErr != nil {
+		return nil, m.eventsErr
+	}
+	return m.events, nil
+}
+
+// newTestRunTimelineMock returns an applied run that waited 8s in the queue,
+// planned for 1m, waited 5m for confirmation by alice, and applied for 2m.
+func newTestRunTimelineMock() *mockRunTimelineService {
+	t0 := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	return &mockRunTimelineService{
+		run: &tfe.Run{
+			ID:        "run-abc123",
+			Status:    tfe.RunApplied,
+			CreatedAt: t0,
+			StatusTimestamps: &tfe.RunStatusTimestamps{
+				PlanQueuedAt:    t0.Add(2 * time.Second),
+				PlanningAt:      t0.Add(10 * time.Second),
+				PlannedAt:       t0.Add(70 * time.Second),
+				CostEstimatedAt: t0.Add(80 * time.Second),
+				ConfirmedAt:     t0.Add(380 * time.Second),
+				ApplyQueuedAt:   t0.Add(381 * time.Second),
+				ApplyingAt:      t0.Add(385 * time.Second),
+				AppliedAt:       t0.Add(505 * time.Second),
+			},
+		},
+		events: []*tfe.RunEvent{
+			{Action: "applied", Description: "Applied", CreatedAt: t0.Add(505 * time.Second)},
+			{
+				Action:      "confirmed",
+				Description: "Run confirmed",
+				CreatedAt:   t0.Add(380 * time.Second),
+				Actor:       &tfe.User{Username: "alice"},
+				Comment:     &tfe.Comment{Body: "LGTM, ship it"},
+			},
+			{Action: "queued", Description: "Run queued", CreatedAt: t0},
+		},
+	}
+}
+
+func TestStatusTransitions(t *testing.T) {
+	mock := newTestRunTimelineMock()
+
+	transitions := statusTransitions(mock.run)
+	var statuses []string
+	for _, st := range transitions {
+		statuses = append(statuses, st.Status)
+	}
+	want := "pending,plan_queued,planning,planned,cost_estimated,confirmed,apply_queued,applying,applied"
+	if strings.Join(statuses, ",") != want {
+		t.Errorf("statuses = %v, want %s", statuses, want)
+	}
+	if d := transitions[0].DurationSeconds; d == nil || *d != 2 {
+		t.Errorf("expected pending duration 2s, got %v", d)
+	}
+	if d := transitions[len(transitions)-1].DurationSeconds; d != nil {
+		t.Errorf("expected no duration for the current state, got %d", *d)
+	}
+}
+
+func TestStatusTransitions_NoTimestamps(t *testing.T) {
+	transitions := statusTransitions(&tfe.Run{Status: tfe.RunPending, CreatedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)})
+	if len(transitions) != 1 || transitions[0].Status != "pending" {
+		t.Errorf("expected only pending transition, got %v", transitions)
+	}
+}
+
+func TestTimelinePhases(t *testing.T) {
+	phases := timelinePhases(newTestRunTimelineMock().run)
+
+	want := map[string]int64{
+		"Queued":            8,
+		"Plan":              60,
+		"Confirmation Wait": 300,
+		"Apply":             120,
+		"Total":             505,
+	}
+	if len(phases) != len(want) {
+		t.Fatalf("expected %d phases, got %v", len(want), phases)
+	}
+	for _, p := range phases {
+		if p.DurationSeconds != want[p.Name] {
+			t.Errorf("%s duration = %d, want %d", p.Name, p.DurationSeconds, want[p.Name])
+		}
+	}
+}
+
+func TestTimelinePhases_InProgress(t *testing.T) {
+	t0 := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	phases := timelinePhases(&tfe.Run{
+		Status:    tfe.RunPlanning,
+		CreatedAt: t0,
+		StatusTimestamps: &tfe.RunStatusTimestamps{
+			PlanQueuedAt: t0.Add(2 * time.Second),
+			PlanningAt:   t0.Add(5 * time.Second),
+		},
+	})
+	if len(phases) != 1 || phases[0].Name != "Queued" || phases[0].DurationSeconds != 3 {
+		t.Errorf("expected only the queued phase of 3s, got %v", phases)
+	}
+}
+
+func TestTimelinePhases_QueuedFromQueuing(t *testing.T) {
+	t0 := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	phases := timelinePhases(&tfe.Run{
+		Status:    tfe.RunPlanning,
+		CreatedAt: t0,
+		StatusTimestamps: &tfe.RunStatusTimestamps{
+			QueuingAt:  t0.Add(20 * time.Second),
+			PlanningAt: t0.Add(25 * time.Second),
+		},
+	})
+	if len(phases) != 1 || phases[0].Name != "Queued" || phases[0].DurationSeconds != 5 {
+		t.Errorf("expected the queued phase to start when the run was queuing, got %v", phases)
+	}
+
+	phases = timelinePhases(&tfe.Run{
+		Status:           tfe.RunPlanning,
+		CreatedAt:        t0,
+		StatusTimestamps: &tfe.RunStatusTimestamps{PlanningAt: t0.Add(25 * time.Second)},
+	})
+	if len(phases) != 0 {
+		t.Errorf("expected no queued phase without a queued timestamp, got %v", phases)
+	}
+}
+
+func TestTimelinePhases_ApplyErrored(t *testing.T) {
+	t0 := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	phases := timelinePhases(&tfe.Run{
+		Status:    tfe.RunErrored,
+		CreatedAt: t0,
+		StatusTimestamps: &tfe.RunStatusTimestamps{
+			ApplyingAt: t0.Add(10 * time.Second),
+			ErroredAt:  t0.Add(40 * time.Second),
+		},
+	})
+	for _, p := range phases {
+		if p.Name == "Apply" {
+			if p.DurationSeconds != 30 {
+				t.Errorf("expected apply duration 30s, got %d", p.DurationSeconds)
+			}
+			return
+		}
+	}
+	t.Errorf("expected an apply phase, got %v", phases)
+}
+
+func TestRunTimeline_Table(t *testing.T) {
+	viper.Reset()
+
+	out, err := captureStdout(t, func() error {
+		return runRunTimeline(newTestRunTimelineMock(), "run-abc123")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Run: run-abc123 (applied)",
+		"Transitions:", "TIME", "STATUS", "DURATION", "2024-03-15 12:00:10", "planning", "1m0s",
+		"Phases:", "Confirmation Wait:", "5m0s", "Total:", "8m25s",
+		"Events:", "Run confirmed", "alice", "LGTM, ship it",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "Run queued") > strings.Index(out, "Run confirmed") {
+		t.Errorf("expected events in chronological order, got:\n%s", out)
+	}
+}
+
+func TestRunTimeline_JSON(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+
+	out, err := captureStdout(t, func() error {
+		return runRunTimeline(newTestRunTimelineMock(), "run-abc123")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		`"run_id": "run-abc123"`,
+		`"status": "plan_queued"`,
+		`"duration_seconds": 60`,
+		`"name": "Confirmation Wait"`,
+		`"actor": "alice"`,
+		`"comment": "LGTM, ship it"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in JSON output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunTimeline_Errors(t *testing.T) {
+	viper.Reset()
+
+	tests := []struct {
+		name string
+		mock *mockRunTimelineService
+		want string
+	}{
+		{"read run", &mockRunTimelineService{readErr: errors.New("not found")}, `failed to read run "run-abc123"`},
+		{"list events", &mockRunTimelineService{run: &tfe.Run{ID: "run-abc123"}, eventsErr: errors.New("forbidden")}, "failed to list run events"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runRunTimeline(tt.mock, "run-abc123")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestRunTimeline_NoArgs(t *testing.T) {
+	viper.Reset()
+
+	cmd := newCmdRunTimelineWith(func() (runTimelineService, error) {
+		return newTestRunTimelineMock(), nil
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{})
+
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected error for missing run ID")
+	}
+}
@@ -0,0 +1,354 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+	"github.com/nnstt1/hcpt/internal/output"
+)
+
+// runWaitService provides the run reads needed to wait for runs.
+type runWaitService interface {
+	client.RunService
+}
+
+// prRunsService finds the runs reported on a pull request.
+type prRunsService interface {
+	ListRunsFromPR(ctx context.Context, owner, repo string, prNumber int) ([]client.StatusRun, error)
+}
+
+type runWaitClientFactory func() (runWaitService, error)
+
+type prRunsClientFactory func() (prRunsService, error)
+
+func defaultRunWaitClientFactory() (runWaitService, error) {
+	return client.NewClientWrapper()
+}
+
+func defaultPRRunsClientFactory() (prRunsService, error) {
+	return client.NewGitHubClientWrapper()
+}
+
+type waitRunJSON struct {
+	Workspace            string `json:"workspace"`
+	RunID                string `json:"run_id"`
+	Status               string `json:"status"`
+	HasChanges           bool   `json:"has_changes"`
+	ResourceAdditions    int    `json:"resource_additions"`
+	ResourceChanges      int    `json:"resource_changes"`
+	ResourceDestructions int    `json:"resource_destructions"`
+	ExitCode             int    `json:"exit_code"`
+}
+
+// waitedRun is a run reported on a pull request with its latest state.
+type waitedRun struct {
+	Status client.StatusRun
+	Run    *tfe.Run
+}
+
+// waitDone is the watch condition of run wait: runs that await confirmation
+// or a policy override need attention and are not waited for.
+var waitDone = runShowOptions{StopOnConfirmation: true}
+
+func newCmdRunWait() *cobra.Command {
+	return newCmdRunWaitWith(defaultRunWaitClientFactory, defaultPRRunsClientFactory)
+}
+
+func newCmdRunWaitWith(clientFn runWaitClientFactory, prClientFn prRunsClientFactory) *cobra.Command {
+	var prNumber int
+	var repoFullName string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for all runs of a pull request to complete",
+		Long: `Wait for every HCP Terraform run reported on the head commit of a GitHub
+pull request, printing a progress table whenever a run changes status.
+
+Runs that are reported after the wait began are waited for as well, a run
+retried on the same commit replaces the previous one, and the command keeps
+polling until a run is reported. Runs awaiting confirmation or a
+policy override need attention and are not waited for. The command exits with the code of the most severe outcome (see
+the exit codes of run show --watch), or 0 if every run succeeded.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if prNumber <= 0 {
+				return fmt.Errorf("--pr is required")
+			}
+			if timeout < 0 {
+				return fmt.Errorf("--timeout must not be negative")
+			}
+
+			if repoFullName == "" {
+				// Try to auto-detect repository from Git remote
+				detectedRepo, err := client.DetectGitHubRepository(context.Background())
+				if err != nil {
+					return err
+				}
+				repoFullName = detectedRepo
+			}
+
+			owner, repo, ok := strings.Cut(repoFullName, "/")
+			if !ok {
+				return fmt.Errorf("--repo must be in format 'owner/repo'")
+			}
+
+			prSvc, err := prClientFn()
+			if err != nil {
+				return err
+			}
+			svc, err := clientFn()
+			if err != nil {
+				return err
+			}
+			return runRunWait(svc, prSvc, owner, repo, prNumber, timeout)
+		},
+	}
+
+	cmd.Flags().IntVarP(&prNumber, "pr", "p", 0, "GitHub pull request number (required)")
+	cmd.Flags().StringVarP(&repoFullName, "repo", "r", "", "GitHub repository (owner/repo)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "stop waiting after this duration (e.g. 30m)")
+
+	return cmd
+}
+
+func runRunWait(svc runWaitService, prSvc prRunsService, owner, repo string, prNumber int, timeout time.Duration) error {
+	return runRunWaitWithInterval(svc, prSvc, owner, repo, prNumber, timeout, 5*time.Second)
+}
+
+func runRunWaitWithInterval(svc runWaitService, prSvc prRunsService, owner, repo string, prNumber int, timeout, pollInterval time.Duration) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+		defer cancelTimeout()
+	}
+
+	var runs []*waitedRun
+	if _, err := addReportedRuns(ctx, svc, prSvc, owner, repo, prNumber, &runs); err != nil {
+		return err
+	}
+
+	if !viper.GetBool("json") {
+		if len(runs) == 0 {
+			_, _ = fmt.Fprintf(os.Stdout, "Waiting for HCP Terraform runs to be reported on PR #%d...\n", prNumber)
+		} else {
+			printWaitProgress(os.Stdout, time.Now(), runs)
+		}
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for len(runs) == 0 || !allRunsDone(runs) {
+		select {
+		case <-ctx.Done():
+			if err := printWaitJSON(runs); err != nil {
+				return err
+			}
+			return waitStopped(ctx, runs, prNumber, timeout)
+		case <-ticker.C:
+			found := len(runs)
+			reported, err := addReportedRuns(ctx, svc, prSvc, owner, repo, prNumber, &runs)
+			if err != nil && ctx.Err() == nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			if pollWaitedRuns(ctx, svc, runs[:found]) {
+				reported = true
+			}
+			if reported && !viper.GetBool("json") {
+				_, _ = fmt.Fprintln(os.Stdout, "")
+				printWaitProgress(os.Stdout, time.Now(), runs)
+			}
+		}
+	}
+
+	if err := printWaitJSON(runs); err != nil {
+		return err
+	}
+	return waitExitError(runs)
+}
+
+// addReportedRuns lists the runs reported on the pull request and updates the
+// runs waited for, one per status context: runs which start after the wait
+// began are appended, and a run replaced by a new one, e.g. when it is
+// retried on the same commit, is swapped for it. It reports whether any run
+// was added or replaced.
+func addReportedRuns(ctx context.Context, svc runWaitService, prSvc prRunsService, owner, repo string, prNumber int, runs *[]*waitedRun) (bool, error) {
+	statusRuns, err := prSvc.ListRunsFromPR(ctx, owner, repo, prNumber)
+	if err != nil {
+		return false, err
+	}
+
+	byContext := make(map[string]*waitedRun, len(*runs))
+	for _, wr := range *runs {
+		byContext[wr.Status.Context] = wr
+	}
+	changed := false
+	for _, sr := range statusRuns {
+		wr, known := byContext[sr.Context]
+		if known && wr.Run.ID == sr.RunID {
+			continue
+		}
+		r, err := svc.ReadRun(ctx, sr.RunID)
+		if err != nil {
+			return changed, fmt.Errorf("failed to read run %q: %w", sr.RunID, err)
+		}
+		changed = true
+		if known {
+			wr.Status, wr.Run = sr, r
+			continue
+		}
+		wr = &waitedRun{Status: sr, Run: r}
+		byContext[sr.Context] = wr
+		*runs = append(*runs, wr)
+	}
+	return changed, nil
+}
+
+// pollWaitedRuns reads the runs that are not done concurrently and reports
+// whether any of them changed status. Read errors are reported as warnings,
+// as they are possibly transient.
+func pollWaitedRuns(ctx context.Context, svc runWaitService, runs []*waitedRun) bool {
+	updated := make([]*tfe.Run, len(runs))
+	var wg sync.WaitGroup
+	for i, wr := range runs {
+		if isWatchDone(wr.Run, waitDone) {
+			continue
+		}
+		wg.Go(func() {
+			r, err := svc.ReadRun(ctx, wr.Run.ID)
+			if err != nil {
+				if ctx.Err() == nil {
+					_, _ = fmt.Fprintf(os.Stderr, "Warning: failed to read run %s: %v\n", wr.Run.ID, err)
+				}
+				return
+			}
+			updated[i] = r
+		})
+	}
+	wg.Wait()
+
+	changed := false
+	for i, r := range updated {
+		if r == nil {
+			continue
+		}
+		if r.Status != runs[i].Run.Status {
+			changed = true
+		}
+		runs[i].Run = r
+	}
+	return changed
+}
+
+func allRunsDone(runs []*waitedRun) bool {
+	for _, wr := range runs {
+		if !isWatchDone(wr.Run, waitDone) {
+			return false
+		}
+	}
+	return true
+}
+
+// printWaitProgress writes the status of every run as a table, preceded by
+// the time of the update.
+func printWaitProgress(w io.Writer, timestamp time.Time, runs []*waitedRun) {
+	done := 0
+	rows := make([][]string, 0, len(runs))
+	for _, wr := range runs {
+		if isWatchDone(wr.Run, waitDone) {
+			done++
+		}
+		rows = append(rows, []string{statusRunWorkspace(wr.Status), wr.Run.ID, string(wr.Run.Status), planChangeCounts(wr.Run)})
+	}
+	_, _ = fmt.Fprintf(w, "%s  %d/%d runs done\n", timestamp.Format("2006-01-02 15:04:05"), done, len(runs))
+	output.Print(w, []string{"WORKSPACE", "RUN", "STATUS", "CHANGES"}, rows)
+}
+
+// printWaitJSON writes the final state of the runs in JSON mode.
+func printWaitJSON(runs []*waitedRun) error {
+	if !viper.GetBool("json") {
+		return nil
+	}
+	items := make([]waitRunJSON, 0, len(runs))
+	for _, wr := range runs {
+		item := waitRunJSON{
+			Workspace:  statusRunWorkspace(wr.Status),
+			RunID:      wr.Run.ID,
+			Status:     string(wr.Run.Status),
+			HasChanges: wr.Run.HasChanges,
+		}
+		if wr.Run.Plan != nil {
+			item.ResourceAdditions = wr.Run.Plan.ResourceAdditions
+			item.ResourceChanges = wr.Run.Plan.ResourceChanges
+			item.ResourceDestructions = wr.Run.Plan.ResourceDestructions
+		}
+		if isWatchDone(wr.Run, waitDone) {
+			var exitErr *ExitError
+			if errors.As(watchExitError(wr.Run), &exitErr) {
+				item.ExitCode = exitErr.Code
+			}
+		}
+		items = append(items, item)
+	}
+	return output.PrintJSON(os.Stdout, items)
+}
+
+// waitExitError returns the error for runs that did not succeed, with the
+// lowest of their exit codes so that errored runs take precedence, or nil if
+// every run succeeded.
+func waitExitError(runs []*waitedRun) error {
+	code := 0
+	var failed []string
+	for _, wr := range runs {
+		var exitErr *ExitError
+		if !errors.As(watchExitError(wr.Run), &exitErr) {
+			continue
+		}
+		if code == 0 || exitErr.Code < code {
+			code = exitErr.Code
+		}
+		failed = append(failed, fmt.Sprintf("%s (%s: %s)", wr.Run.ID, statusRunWorkspace(wr.Status), wr.Run.Status))
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &ExitError{Code: code, Err: fmt.Errorf("%d of %d runs did not succeed: %s", len(failed), len(runs), strings.Join(failed, ", "))}
+}
+
+// waitStopped returns the error for a wait that ended before the runs did,
+// either by the --timeout deadline or by an interrupt.
+func waitStopped(ctx context.Context, runs []*waitedRun, prNumber int, timeout time.Duration) error {
+	if len(runs) == 0 {
+		err := fmt.Errorf("no HCP Terraform run found in PR #%d", prNumber)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return &ExitError{Code: exitCodeTimeout, Err: fmt.Errorf("timed out after %s: %w", timeout, err)}
+		}
+		return &ExitError{Code: exitCodeInterrupted, Err: fmt.Errorf("interrupted: %w", err)}
+	}
+	var pending []string
+	for _, wr := range runs {
+		if !isWatchDone(wr.Run, waitDone) {
+			pending = append(pending, fmt.Sprintf("%s (%s: %s)", wr.Run.ID, statusRunWorkspace(wr.Status), wr.Run.Status))
+		}
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &ExitError{Code: exitCodeTimeout, Err: fmt.Errorf("timed out after %s waiting for %d of %d runs: %s", timeout, len(pending), len(runs), strings.Join(pending, ", "))}
+	}
+	return &ExitError{Code: exitCodeInterrupted, Err: fmt.Errorf("interrupted while waiting for %d of %d runs: %s", len(pending), len(runs), strings.Join(pending, ", "))}
+}
@@ -0,0 +1,348 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/spf13/viper"
+
+	"github.com/nnstt1/hcpt/internal/client"
+)
+
+// mockRunWaitService returns the runs of each run ID in order, repeating the
+// last one once they are exhausted.
+type mockRunWaitService struct {
+	mu    sync.Mutex
+	runs  map[string][]*tfe.Run
+	reads map[string]int
+}
+
+func (m *mockRunWaitService) ListRuns(_ context.Context, _ string, _ *tfe.RunListOptions) (*tfe.RunList, error) {
+	return nil, nil
+}
+
+func (m *mockRunWaitService) ReadRun(_ context.Context, runID string) (*tfe.Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	runs, ok := m.runs[runID]
+	if !ok {
+		return nil, fmt.Errorf("run not found")
+	}
+	if m.reads == nil {
+		m.reads = make(map[string]int)
+	}
+	i := min(m.reads[runID], len(runs)-1)
+	m.reads[runID]++
+	return runs[i], nil
+}
+
+func (m *mockRunWaitService) ReadRunWithApply(ctx context.Context, runID string) (*tfe.Run, error) {
+	return m.ReadRun(ctx, runID)
+}
+
+func (m *mockRunWaitService) CreateRun(_ context.Context, _ tfe.RunCreateOptions) (*tfe.Run, error) {
+	return nil, nil
+}
+
+// mockPRRunsSequence returns the reported runs of each call in order,
+// repeating the last ones once they are exhausted.
+type mockPRRunsSequence struct {
+	mu    sync.Mutex
+	calls [][]client.StatusRun
+	lists int
+}
+
+func (m *mockPRRunsSequence) ListRunsFromPR(_ context.Context, _, _ string, _ int) ([]client.StatusRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := min(m.lists, len(m.calls)-1)
+	m.lists++
+	return m.calls[i], nil
+}
+
+func newTestPRRunsService() *mockPRCommentService {
+	return &mockPRCommentService{
+		runs: []client.StatusRun{
+			{Context: "HCP Terraform / my-org / ws-a", Workspace: "ws-a", RunID: "run-aaaa"},
+			{Context: "HCP Terraform / my-org / ws-b", Workspace: "ws-b", RunID: "run-bbbb"},
+		},
+	}
+}
+
+func TestRunWait(t *testing.T) {
+	viper.Reset()
+	svc := &mockRunWaitService{
+		runs: map[string][]*tfe.Run{
+			"run-aaaa": {
+				{ID: "run-aaaa", Status: tfe.RunPlanning, Plan: &tfe.Plan{Status: tfe.PlanRunning}},
+				{ID: "run-aaaa", Status: tfe.RunPlannedAndFinished, Plan: &tfe.Plan{Status: tfe.PlanFinished, ResourceAdditions: 1}},
+			},
+			"run-bbbb": {
+				{ID: "run-bbbb", Status: tfe.RunPending},
+				{ID: "run-bbbb", Status: tfe.RunPlanning},
+				{ID: "run-bbbb", Status: tfe.RunApplied, Plan: &tfe.Plan{Status: tfe.PlanFinished, ResourceChanges: 2}},
+			},
+		},
+	}
+
+	out, err := captureStdout(t, func() error {
+		return runRunWaitWithInterval(svc, newTestPRRunsService(), "owner", "repo", 42, 0, time.Millisecond)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{"0/2 runs done", "1/2 runs done", "2/2 runs done", "WORKSPACE", "CHANGES"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	last := strings.Join(lines[len(lines)-2:], "\n")
+	if !strings.Contains(last, "planned_and_finished") || !strings.Contains(last, "+1 ~0 -0") {
+		t.Errorf("expected final table to show run-aaaa finished, got:\n%s", out)
+	}
+}
+
+func TestRunWait_Failures(t *testing.T) {
+	tests := []struct {
+		name     string
+		runA     *tfe.Run
+		runB     *tfe.Run
+		wantCode int
+		wantMsg  string
+	}{
+		{
+			name:     "errored takes precedence",
+			runA:     &tfe.Run{ID: "run-aaaa", Status: tfe.RunErrored},
+			runB:     &tfe.Run{ID: "run-bbbb", Status: tfe.RunPlanned, Actions: &tfe.RunActions{IsConfirmable: true}},
+			wantCode: exitCodeErrored,
+			wantMsg:  "2 of 2 runs did not succeed: run-aaaa (ws-a: errored), run-bbbb (ws-b: planned)",
+		},
+		{
+			name:     "needs confirmation",
+			runA:     &tfe.Run{ID: "run-aaaa", Status: tfe.RunApplied},
+			runB:     &tfe.Run{ID: "run-bbbb", Status: tfe.RunPlanned, Actions: &tfe.RunActions{IsConfirmable: true}},
+			wantCode: exitCodeNeedsConfirmation,
+			wantMsg:  "1 of 2 runs did not succeed: run-bbbb (ws-b: planned)",
+		},
+		{
+			name:     "policy soft failed",
+			runA:     &tfe.Run{ID: "run-aaaa", Status: tfe.RunPolicySoftFailed},
+			runB:     &tfe.Run{ID: "run-bbbb", Status: tfe.RunPlannedAndFinished},
+			wantCode: exitCodePolicySoftFailed,
+			wantMsg:  "1 of 2 runs did not succeed: run-aaaa (ws-a: policy_soft_failed)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			svc := &mockRunWaitService{
+				runs: map[string][]*tfe.Run{"run-aaaa": {tt.runA}, "run-bbbb": {tt.runB}},
+			}
+
+			_, err := captureStdout(t, func() error {
+				return runRunWaitWithInterval(svc, newTestPRRunsService(), "owner", "repo", 42, 0, time.Millisecond)
+			})
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) || exitErr.Code != tt.wantCode {
+				t.Fatalf("expected exit code %d, got %v", tt.wantCode, err)
+			}
+			if err.Error() != tt.wantMsg {
+				t.Errorf("expected error %q, got %q", tt.wantMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestRunWait_Timeout(t *testing.T) {
+	viper.Reset()
+	svc := &mockRunWaitService{
+		runs: map[string][]*tfe.Run{
+			"run-aaaa": {{ID: "run-aaaa", Status: tfe.RunPlannedAndFinished}},
+			"run-bbbb": {{ID: "run-bbbb", Status: tfe.RunPlanning}},
+		},
+	}
+
+	_, err := captureStdout(t, func() error {
+		return runRunWaitWithInterval(svc, newTestPRRunsService(), "owner", "repo", 42, 30*time.Millisecond, 10*time.Millisecond)
+	})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitCodeTimeout {
+		t.Fatalf("expected exit code %d, got %v", exitCodeTimeout, err)
+	}
+	if !strings.Contains(err.Error(), "timed out after 30ms waiting for 1 of 2 runs: run-bbbb (ws-b: planning)") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestRunWait_JSON(t *testing.T) {
+	viper.Reset()
+	viper.Set("json", true)
+	defer viper.Reset()
+	svc := &mockRunWaitService{
+		runs: map[string][]*tfe.Run{
+			"run-aaaa": {{ID: "run-aaaa", Status: tfe.RunApplied, HasChanges: true, Plan: &tfe.Plan{ResourceAdditions: 3}}},
+			"run-bbbb": {{ID: "run-bbbb", Status: tfe.RunErrored}},
+		},
+	}
+
+	out, err := captureStdout(t, func() error {
+		return runRunWaitWithInterval(svc, newTestPRRunsService(), "owner", "repo", 42, 0, time.Millisecond)
+	})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitCodeErrored {
+		t.Fatalf("expected exit code %d, got %v", exitCodeErrored, err)
+	}
+
+	var got []waitRunJSON
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("failed to parse JSON output %q: %v", out, err)
+	}
+	want := []waitRunJSON{
+		{Workspace: "ws-a", RunID: "run-aaaa", Status: "applied", HasChanges: true, ResourceAdditions: 3},
+		{Workspace: "ws-b", RunID: "run-bbbb", Status: "errored", ExitCode: exitCodeErrored},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d runs, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("run %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRunWait_NoRuns(t *testing.T) {
+	viper.Reset()
+
+	_, err := captureStdout(t, func() error {
+		return runRunWaitWithInterval(&mockRunWaitService{}, &mockPRCommentService{}, "owner", "repo", 42, 30*time.Millisecond, 10*time.Millisecond)
+	})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitCodeTimeout {
+		t.Fatalf("expected exit code %d, got %v", exitCodeTimeout, err)
+	}
+	if err.Error() != "timed out after 30ms: no HCP Terraform run found in PR #42" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunWait_RunsReportedLater(t *testing.T) {
+	viper.Reset()
+	runA := client.StatusRun{Context: "HCP Terraform / my-org / ws-a", Workspace: "ws-a", RunID: "run-aaaa"}
+	runB := client.StatusRun{Context: "HCP Terraform / my-org / ws-b", Workspace: "ws-b", RunID: "run-bbbb"}
+	prSvc := &mockPRRunsSequence{
+		calls: [][]client.StatusRun{
+			nil,
+			{runA},
+			{runA, runB},
+		},
+	}
+	svc := &mockRunWaitService{
+		runs: map[string][]*tfe.Run{
+			"run-aaaa": {
+				{ID: "run-aaaa", Status: tfe.RunPlanning},
+				{ID: "run-aaaa", Status: tfe.RunPlanning},
+				{ID: "run-aaaa", Status: tfe.RunPlannedAndFinished},
+			},
+			"run-bbbb": {{ID: "run-bbbb", Status: tfe.RunApplied}},
+		},
+	}
+
+	out, err := captureStdout(t, func() error {
+		return runRunWaitWithInterval(svc, prSvc, "owner", "repo", 42, 0, time.Millisecond)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{"Waiting for HCP Terraform runs to be reported on PR #42", "0/1 runs done", "1/2 runs done", "2/2 runs done"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if prSvc.lists < 3 {
+		t.Errorf("expected the PR to be listed until every run was reported, got %d lists", prSvc.lists)
+	}
+}
+
+func TestRunWait_RetriedRun(t *testing.T) {
+	viper.Reset()
+	runA := client.StatusRun{Context: "HCP Terraform / my-org / ws-a", Workspace: "ws-a", RunID: "run-aaaa"}
+	retryA := client.StatusRun{Context: "HCP Terraform / my-org / ws-a", Workspace: "ws-a", RunID: "run-cccc"}
+	runB := client.StatusRun{Context: "HCP Terraform / my-org / ws-b", Workspace: "ws-b", RunID: "run-bbbb"}
+	prSvc := &mockPRRunsSequence{
+		calls: [][]client.StatusRun{
+			{runA, runB},
+			{retryA, runB},
+		},
+	}
+	svc := &mockRunWaitService{
+		runs: map[string][]*tfe.Run{
+			"run-aaaa": {{ID: "run-aaaa", Status: tfe.RunErrored}},
+			"run-bbbb": {
+				{ID: "run-bbbb", Status: tfe.RunPlanning},
+				{ID: "run-bbbb", Status: tfe.RunPlanning},
+				{ID: "run-bbbb", Status: tfe.RunApplied},
+			},
+			"run-cccc": {
+				{ID: "run-cccc", Status: tfe.RunPlanning},
+				{ID: "run-cccc", Status: tfe.RunPlanning},
+				{ID: "run-cccc", Status: tfe.RunApplied},
+			},
+		},
+	}
+
+	out, err := captureStdout(t, func() error {
+		return runRunWaitWithInterval(svc, prSvc, "owner", "repo", 42, 0, time.Millisecond)
+	})
+	if err != nil {
+		t.Fatalf("expected the retried run to replace the errored one, got: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	last := strings.Join(lines[len(lines)-4:], "\n")
+	if !strings.Contains(last, "2/2 runs done") || !strings.Contains(last, "run-cccc") || strings.Contains(last, "run-aaaa") {
+		t.Errorf("expected the final table to show the retried run only, got:\n%s", out)
+	}
+}
+
+func TestRunWait_Validation(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--repo", "owner/repo"}, "--pr is required"},
+		{[]string{"--pr", "42", "--repo", "repo"}, "--repo must be in format 'owner/repo'"},
+		{[]string{"--pr", "42", "--repo", "owner/repo", "--timeout", "-1s"}, "--timeout must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantErr, func(t *testing.T) {
+			viper.Reset()
+
+			cmd := newCmdRunWaitWith(
+				func() (runWaitService, error) { return &mockRunWaitService{}, nil },
+				func() (prRunsService, error) { return &mockPRCommentService{}, nil },
+			)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected %q error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
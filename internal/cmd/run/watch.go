@@ -2,6 +2,8 @@ package run
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -10,26 +12,32 @@ import (
 	"github.com/spf13/viper"
 )
 
-// watchRun polls the run status until it reaches a terminal state.
-func watchRun(ctx context.Context, svc runShowService, runID string, initialRun *tfe.Run, pollInterval time.Duration) error {
-	// In watch mode, skip fetching resource changes (inefficient to fetch on every poll)
-	var resourceChanges []resourceChange
+// watchRun polls the run status until it reaches a terminal state, or with
+// opts.StopOnConfirmation, a state that waits for a user. The returned
+// ExitError reflects the final status, a timeout, or an interrupt.
+func watchRun(ctx context.Context, svc runShowService, runID string, initialRun *tfe.Run, opts runShowOptions, pollInterval time.Duration) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	if opts.Events {
+		return watchRunEvents(ctx, svc, runID, initialRun, opts, pollInterval)
+	}
 
 	// If already in terminal status, display only the initial output and exit
-	if isTerminalStatus(initialRun.Status) {
-		// Fetch resource changes only when terminal status is reached
-		if initialRun.Plan != nil && initialRun.HasChanges {
-			planJSONBytes, err := svc.ReadPlanJSONOutput(ctx, initialRun.Plan.ID)
-			if err == nil {
-				resourceChanges, _ = extractResourceChanges(planJSONBytes)
-			}
+	if isWatchDone(initialRun, opts) {
+		// Fetch details only when terminal status is reached (inefficient to fetch on every poll)
+		if err := displayRun(initialRun, fetchRunDetails(ctx, svc, initialRun, opts)); err != nil {
+			return err
 		}
-		return displayRun(initialRun, resourceChanges)
+		return watchExitError(initialRun)
 	}
 
 	// In non-JSON mode, display initial output and separator
 	if !viper.GetBool("json") {
-		if err := displayRun(initialRun, nil); err != nil {
+		if err := displayRun(initialRun, runDetails{}); err != nil {
 			return err
 		}
 		_, _ = fmt.Fprintln(os.Stdout, "---")
@@ -38,10 +46,11 @@ func watchRun(ctx context.Context, svc runShowService, runID string, initialRun
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
+	last := initialRun.Status
 	for {
 		select {
 		case <-ctx.Done():
-			return nil
+			return watchStopped(ctx, runID, last, opts.Timeout)
 		case <-ticker.C:
 			r, err := svc.ReadRun(ctx, runID)
 			if err != nil {
@@ -49,6 +58,7 @@ func watchRun(ctx context.Context, svc runShowService, runID string, initialRun
 				fmt.Fprintf(os.Stderr, "Warning: failed to read run: %v\n", err)
 				continue
 			}
+			last = r.Status
 
 			// Output status on each poll
 			if !viper.GetBool("json") {
@@ -56,24 +66,159 @@ func watchRun(ctx context.Context, svc runShowService, runID string, initialRun
 			}
 
 			// When terminal status is reached, display final result and exit
-			if isTerminalStatus(r.Status) {
-				// Fetch resource changes when terminal status is reached
-				var finalChanges []resourceChange
-				if r.Plan != nil && r.HasChanges {
-					planJSONBytes, err := svc.ReadPlanJSONOutput(ctx, r.Plan.ID)
-					if err == nil {
-						finalChanges, _ = extractResourceChanges(planJSONBytes)
-					}
-				}
+			if isWatchDone(r, opts) {
 				if !viper.GetBool("json") {
 					_, _ = fmt.Fprintln(os.Stdout, "---")
 				}
-				return displayRun(r, finalChanges)
+				if err := displayRun(r, fetchRunDetails(ctx, svc, r, opts)); err != nil {
+					return err
+				}
+				return watchExitError(r)
+			}
+		}
+	}
+}
+
+// isWatchDone reports whether watching a run should stop at its status.
+func isWatchDone(r *tfe.Run, opts runShowOptions) bool {
+	return isTerminalStatus(r.Status) || (opts.StopOnConfirmation && isAwaitingUser(r))
+}
+
+// isAwaitingUser reports whether a run waits for a user to confirm it or to
+// override a soft-mandatory policy failure.
+func isAwaitingUser(r *tfe.Run) bool {
+	if r.Status == tfe.RunPolicySoftFailed {
+		return true
+	}
+	if r.Actions != nil {
+		return r.Actions.IsConfirmable
+	}
+	return r.Status == tfe.RunPlanned
+}
+
+// watchStopped returns the error for a watch that ended before the run did,
+// either by the --timeout deadline or by an interrupt.
+func watchStopped(ctx context.Context, runID string, status tfe.RunStatus, timeout time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &ExitError{Code: exitCodeTimeout, Err: fmt.Errorf("timed out after %s waiting for run %s (status: %s)", timeout, runID, status)}
+	}
+	return &ExitError{Code: exitCodeInterrupted, Err: fmt.Errorf("interrupted while watching run %s (status: %s)", runID, status)}
+}
+
+// Types of the events emitted by watchRunEvents.
+const (
+	watchEventStatus  = "status"
+	watchEventSummary = "summary"
+)
+
+// watchStatusEvent reports a status the run was observed in. PreviousStatus
+// is empty for the status observed when watching starts.
+type watchStatusEvent struct {
+	Type           string    `json:"type"`
+	Timestamp      time.Time `json:"timestamp"`
+	RunID          string    `json:"run_id"`
+	PreviousStatus string    `json:"previous_status,omitempty"`
+	Status         string    `json:"status"`
+	ElapsedSeconds int64     `json:"elapsed_seconds"`
+}
+
+// watchSummaryEvent is emitted once watching stops at the run's final status.
+type watchSummaryEvent struct {
+	Type                 string    `json:"type"`
+	Timestamp            time.Time `json:"timestamp"`
+	RunID                string    `json:"run_id"`
+	Status               string    `json:"status"`
+	ElapsedSeconds       int64     `json:"elapsed_seconds"`
+	Transitions          int       `json:"transitions"`
+	HasChanges           bool      `json:"has_changes"`
+	ResourceAdditions    int       `json:"resource_additions"`
+	ResourceChanges      int       `json:"resource_changes"`
+	ResourceDestructions int       `json:"resource_destructions"`
+}
+
+// watchRunEvents polls the run status until it reaches a terminal state,
+// writing one JSON object per line for each status change and a final
+// summary. Polls that observe an unchanged status are not reported.
+func watchRunEvents(ctx context.Context, svc runShowService, runID string, initialRun *tfe.Run, opts runShowOptions, pollInterval time.Duration) error {
+	enc := json.NewEncoder(os.Stdout)
+	start := time.Now()
+	transitions := 0
+
+	emitStatus := func(previous tfe.RunStatus, r *tfe.Run) error {
+		now := time.Now()
+		return enc.Encode(watchStatusEvent{
+			Type:           watchEventStatus,
+			Timestamp:      now.UTC(),
+			RunID:          runID,
+			PreviousStatus: string(previous),
+			Status:         string(r.Status),
+			ElapsedSeconds: int64(now.Sub(start).Seconds()),
+		})
+	}
+
+	if err := emitStatus("", initialRun); err != nil {
+		return err
+	}
+	if isWatchDone(initialRun, opts) {
+		if err := writeWatchSummary(enc, runID, initialRun, start, transitions); err != nil {
+			return err
+		}
+		return watchExitError(initialRun)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	last := initialRun.Status
+	for {
+		select {
+		case <-ctx.Done():
+			return watchStopped(ctx, runID, last, opts.Timeout)
+		case <-ticker.C:
+			r, err := svc.ReadRun(ctx, runID)
+			if err != nil {
+				// Possibly a transient error; print warning and continue
+				fmt.Fprintf(os.Stderr, "Warning: failed to read run: %v\n", err)
+				continue
+			}
+
+			if r.Status != last {
+				transitions++
+				if err := emitStatus(last, r); err != nil {
+					return err
+				}
+				last = r.Status
+			}
+
+			if isWatchDone(r, opts) {
+				if err := writeWatchSummary(enc, runID, r, start, transitions); err != nil {
+					return err
+				}
+				return watchExitError(r)
 			}
 		}
 	}
 }
 
+func writeWatchSummary(enc *json.Encoder, runID string, r *tfe.Run, start time.Time, transitions int) error {
+	now := time.Now()
+	summary := watchSummaryEvent{
+		Type:           watchEventSummary,
+		Timestamp:      now.UTC(),
+		RunID:          runID,
+		Status:         string(r.Status),
+		ElapsedSeconds: int64(now.Sub(start).Seconds()),
+		Transitions:    transitions,
+		HasChanges:     r.HasChanges,
+	}
+	if r.Plan != nil {
+		summary.ResourceAdditions = r.Plan.ResourceAdditions
+		summary.ResourceChanges = r.Plan.ResourceChanges
+		summary.ResourceDestructions = r.Plan.ResourceDestructions
+	}
+	return enc.Encode(summary)
+}
+
 // isTerminalStatus returns true if the status is a terminal state.
 func isTerminalStatus(status tfe.RunStatus) bool {
 	switch status {
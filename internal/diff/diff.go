@@ -0,0 +1,340 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Display strings for values that cannot be shown as-is.
+const (
+	NullValue            = "(null)"
+	SensitiveValue       = "(sensitive value)"
+	KnownAfterApplyValue = "(known after apply)"
+)
+
+// Attribute represents a single attribute-level change between before and after states.
+type Attribute struct {
+	Key             string
+	Before          string
+	After           string
+	BeforeRaw       interface{}
+	AfterRaw        interface{}
+	KnownAfterApply bool
+	Sensitive       bool
+}
+
+// Flatten recursively flattens a nested map into dot-notation keys.
+// Empty maps and empty arrays are stored as leaf values to distinguish them from nil.
+func Flatten(prefix string, value interface{}, result map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			result[prefix] = value
+			return
+		}
+		for k, val := range v {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			Flatten(key, val, result)
+		}
+	case []interface{}:
+		if len(v) == 0 && prefix != "" {
+			result[prefix] = value
+			return
+		}
+		for i, val := range v {
+			key := fmt.Sprintf("%s.%d", prefix, i)
+			Flatten(key, val, result)
+		}
+	default:
+		result[prefix] = value
+	}
+}
+
+// IsMarked checks if a flattened key (e.g. "a.b.c") is marked true in
+// flatMarks, a flattened after_unknown or before/after_sensitive structure. It checks
+// the key itself and all ancestor keys, because these structures may mark an
+// entire parent object as true (e.g. "a": true) rather than listing each child
+// individually.
+func IsMarked(key string, flatMarks map[string]interface{}) bool {
+	if flatMarks[key] == true {
+		return true
+	}
+	for i := len(key) - 1; i >= 0; i-- {
+		if key[i] == '.' {
+			if flatMarks[key[:i]] == true {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Compute compares before and after maps and returns sorted attribute diffs.
+// afterUnknown marks attributes whose after value will be known only after apply.
+// beforeSensitive/afterSensitive mark attributes whose values must not be displayed.
+func Compute(before, after, afterUnknown map[string]interface{}, beforeSensitive, afterSensitive interface{}) []Attribute { //nolint:gocyclo // complex by nature, refactor tracked in separate issue
+	flatBefore := make(map[string]interface{})
+	flatAfter := make(map[string]interface{})
+	flatAfterUnknown := make(map[string]interface{})
+	flatBeforeSensitive := make(map[string]interface{})
+	flatAfterSensitive := make(map[string]interface{})
+
+	flattenPair("", before, after, flatBefore, flatAfter)
+	if afterUnknown != nil {
+		Flatten("", afterUnknown, flatAfterUnknown)
+	}
+	if m, ok := beforeSensitive.(map[string]interface{}); ok {
+		Flatten("", m, flatBeforeSensitive)
+	}
+	if m, ok := afterSensitive.(map[string]interface{}); ok {
+		Flatten("", m, flatAfterSensitive)
+	}
+
+	// Collect all keys from before and after
+	keys := make(map[string]struct{})
+	for k := range flatBefore {
+		keys[k] = struct{}{}
+	}
+	for k := range flatAfter {
+		keys[k] = struct{}{}
+	}
+
+	var diffs []Attribute
+	for k := range keys {
+		bVal, bOk := flatBefore[k]
+		aVal, aOk := flatAfter[k]
+		unknown := IsMarked(k, flatAfterUnknown)
+		sensitive := IsMarked(k, flatBeforeSensitive) || IsMarked(k, flatAfterSensitive)
+
+		// Raw strings for change detection; display strings for output
+		rawBStr := FormatValue(bVal)
+		rawAStr := FormatValue(aVal)
+		dispBStr := rawBStr
+		if sensitive {
+			dispBStr = SensitiveValue
+		}
+
+		switch {
+		case !bOk:
+			// Added
+			if aVal == nil && !unknown {
+				continue
+			}
+			dispAStr := KnownAfterApplyValue
+			if !unknown {
+				dispAStr = rawAStr
+				if sensitive {
+					dispAStr = SensitiveValue
+				}
+			}
+			diffs = append(diffs, Attribute{Key: k, Before: NullValue, After: dispAStr, BeforeRaw: nil, AfterRaw: aVal, KnownAfterApply: unknown, Sensitive: sensitive})
+		case !aOk || unknown:
+			// Removed or known-after-apply (key absent from after, or parent marked unknown)
+			if bVal == nil && !unknown {
+				continue
+			}
+			if unknown {
+				diffs = append(diffs, Attribute{Key: k, Before: dispBStr, After: KnownAfterApplyValue, BeforeRaw: bVal, AfterRaw: nil, KnownAfterApply: true, Sensitive: sensitive})
+			} else {
+				diffs = append(diffs, Attribute{Key: k, Before: dispBStr, After: NullValue, BeforeRaw: bVal, AfterRaw: nil, Sensitive: sensitive})
+			}
+		case rawBStr != rawAStr:
+			// Changed — compare raw values; display masked if sensitive
+			dispAStr := rawAStr
+			if sensitive {
+				dispAStr = SensitiveValue
+			}
+			diffs = append(diffs, Attribute{Key: k, Before: dispBStr, After: dispAStr, BeforeRaw: bVal, AfterRaw: aVal, Sensitive: sensitive})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Key < diffs[j].Key
+	})
+
+	return diffs
+}
+
+// flattenPair flattens before and after together so that structural changes
+// line up: lists whose length changed are aligned element by element, so that
+// inserting one element reports a single addition instead of shifting every
+// following index, and strings holding JSON documents (e.g. IAM policies) are
+// compared field by field.
+func flattenPair(prefix string, before, after interface{}, flatBefore, flatAfter map[string]interface{}) {
+	if b, ok := JSONDocument(before); ok {
+		if a, ok := JSONDocument(after); ok && before != after {
+			before, after = b, a
+		}
+	}
+
+	switch b := before.(type) {
+	case map[string]interface{}:
+		a, ok := after.(map[string]interface{})
+		if !ok || (prefix != "" && (len(a) == 0 || len(b) == 0)) {
+			break
+		}
+		for k, bv := range b {
+			key := joinKey(prefix, k)
+			if av, ok := a[k]; ok {
+				flattenPair(key, bv, av, flatBefore, flatAfter)
+			} else {
+				Flatten(key, bv, flatBefore)
+			}
+		}
+		for k, av := range a {
+			if _, ok := b[k]; !ok {
+				Flatten(joinKey(prefix, k), av, flatAfter)
+			}
+		}
+		return
+	case []interface{}:
+		a, ok := after.([]interface{})
+		if !ok || len(a) == 0 || len(b) == 0 {
+			break
+		}
+		if len(a) == len(b) {
+			for i := range b {
+				flattenPair(fmt.Sprintf("%s.%d", prefix, i), b[i], a[i], flatBefore, flatAfter)
+			}
+			return
+		}
+		// Elements present on both sides are unchanged and produce no diff.
+		// Removed elements keep their before index and added elements their
+		// after index, so a removal and an addition at the same index read as
+		// a change of that element.
+		removed, added := AlignLists(b, a)
+		for _, i := range removed {
+			Flatten(fmt.Sprintf("%s.%d", prefix, i), b[i], flatBefore)
+		}
+		for _, j := range added {
+			Flatten(fmt.Sprintf("%s.%d", prefix, j), a[j], flatAfter)
+		}
+		return
+	}
+
+	if before != nil || prefix != "" {
+		Flatten(prefix, before, flatBefore)
+	}
+	if after != nil || prefix != "" {
+		Flatten(prefix, after, flatAfter)
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// AlignLists matches equal elements of before and after in order (longest
+// common subsequence) and returns the indexes of the unmatched elements.
+func AlignLists(before, after []interface{}) (removed, added []int) {
+	bKeys := make([]string, len(before))
+	for i, v := range before {
+		bKeys[i] = canonical(v)
+	}
+	aKeys := make([]string, len(after))
+	for j, v := range after {
+		aKeys[j] = canonical(v)
+	}
+
+	// lcs[i][j] is the LCS length of before[i:] and after[j:]
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if bKeys[i] == aKeys[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case bKeys[i] == aKeys[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			removed = append(removed, i)
+			i++
+		default:
+			added = append(added, j)
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		removed = append(removed, i)
+	}
+	for ; j < len(after); j++ {
+		added = append(added, j)
+	}
+	return removed, added
+}
+
+// canonical returns a comparable encoding of a JSON value.
+func canonical(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// JSONDocument parses a string holding a JSON object or array.
+func JSONDocument(v interface{}) (interface{}, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, false
+	}
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") && !strings.HasPrefix(s, "[") {
+		return nil, false
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		return nil, false
+	}
+	return doc, true
+}
+
+// FormatValue converts a value to a display string.
+func FormatValue(v interface{}) string {
+	if v == nil {
+		return NullValue
+	}
+	switch val := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", val)
+	case float64:
+		if val == float64(int64(val)) {
+			return fmt.Sprintf("%d", int64(val))
+		}
+		return fmt.Sprintf("%g", val)
+	case bool:
+		return strconv.FormatBool(val)
+	case []interface{}:
+		if len(val) == 0 {
+			return "[]"
+		}
+		return fmt.Sprintf("%v", val)
+	case map[string]interface{}:
+		if len(val) == 0 {
+			return "{}"
+		}
+		return fmt.Sprintf("%v", val)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
@@ -0,0 +1,480 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name      string
+		before    map[string]interface{}
+		after     map[string]interface{}
+		wantKeys  []string
+		wantCount int
+	}{
+		{
+			name:      "changed value",
+			before:    map[string]interface{}{"name": "old"},
+			after:     map[string]interface{}{"name": "new"},
+			wantKeys:  []string{"name"},
+			wantCount: 1,
+		},
+		{
+			name:      "added value",
+			before:    map[string]interface{}{},
+			after:     map[string]interface{}{"new_key": "val"},
+			wantKeys:  []string{"new_key"},
+			wantCount: 1,
+		},
+		{
+			name:      "removed value",
+			before:    map[string]interface{}{"old_key": "val"},
+			after:     map[string]interface{}{},
+			wantKeys:  []string{"old_key"},
+			wantCount: 1,
+		},
+		{
+			name:      "no change",
+			before:    map[string]interface{}{"same": "val"},
+			after:     map[string]interface{}{"same": "val"},
+			wantKeys:  nil,
+			wantCount: 0,
+		},
+		{
+			name: "nested map flattened",
+			before: map[string]interface{}{
+				"tags": map[string]interface{}{"env": "prod"},
+			},
+			after: map[string]interface{}{
+				"tags": map[string]interface{}{"env": "staging"},
+			},
+			wantKeys:  []string{"tags.env"},
+			wantCount: 1,
+		},
+		{
+			name: "array flattened",
+			before: map[string]interface{}{
+				"cidrs": []interface{}{"10.0.0.0/16"},
+			},
+			after: map[string]interface{}{
+				"cidrs": []interface{}{"0.0.0.0/0"},
+			},
+			wantKeys:  []string{"cidrs.0"},
+			wantCount: 1,
+		},
+		{
+			name:      "nil before and after",
+			before:    nil,
+			after:     nil,
+			wantKeys:  nil,
+			wantCount: 0,
+		},
+		{
+			name:      "added nil value ignored",
+			before:    map[string]interface{}{},
+			after:     map[string]interface{}{"key": nil},
+			wantKeys:  nil,
+			wantCount: 0,
+		},
+		{
+			name:      "removed nil value ignored",
+			before:    map[string]interface{}{"key": nil},
+			after:     map[string]interface{}{},
+			wantKeys:  nil,
+			wantCount: 0,
+		},
+		{
+			name:      "nil vs empty array",
+			before:    map[string]interface{}{"tags": nil},
+			after:     map[string]interface{}{"tags": []interface{}{}},
+			wantKeys:  []string{"tags"},
+			wantCount: 1,
+		},
+		{
+			name:      "empty array vs nil",
+			before:    map[string]interface{}{"tags": []interface{}{}},
+			after:     map[string]interface{}{"tags": nil},
+			wantKeys:  []string{"tags"},
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := Compute(tt.before, tt.after, nil, nil, nil)
+			if len(diffs) != tt.wantCount {
+				t.Errorf("expected %d diffs, got %d: %+v", tt.wantCount, len(diffs), diffs)
+			}
+			for _, wantKey := range tt.wantKeys {
+				found := false
+				for _, d := range diffs {
+					if d.Key == wantKey {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("expected diff key %q not found in %+v", wantKey, diffs)
+				}
+			}
+		})
+	}
+}
+
+func TestCompute_KnownAfterApply(t *testing.T) {
+	before := map[string]interface{}{
+		"sku":  "Standard_B1ms",
+		"name": "myvm",
+	}
+	after := map[string]interface{}{
+		"sku":  nil, // null because it will be known after apply
+		"name": "myvm",
+	}
+	afterUnknown := map[string]interface{}{
+		"sku": true, // this attribute is known after apply
+	}
+
+	diffs := Compute(before, after, afterUnknown, nil, nil)
+
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d: %+v", len(diffs), diffs)
+	}
+	d := diffs[0]
+	if d.Key != "sku" {
+		t.Errorf("expected key 'sku', got %q", d.Key)
+	}
+	if d.After != "(known after apply)" {
+		t.Errorf("expected After '(known after apply)', got %q", d.After)
+	}
+	if !d.KnownAfterApply {
+		t.Error("expected KnownAfterApply to be true")
+	}
+}
+
+func TestCompute_KnownAfterApply_NilAfterUnknown(t *testing.T) {
+	// nil afterUnknown should not cause panic, null after stays "(null)"
+	before := map[string]interface{}{"key": "value"}
+	after := map[string]interface{}{"key": nil}
+
+	diffs := Compute(before, after, nil, nil, nil)
+
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d", len(diffs))
+	}
+	if diffs[0].After != "(null)" {
+		t.Errorf("expected '(null)', got %q", diffs[0].After)
+	}
+	if diffs[0].KnownAfterApply {
+		t.Error("expected KnownAfterApply to be false")
+	}
+}
+
+func TestCompute_KnownAfterApply_ParentKey(t *testing.T) {
+	// after_unknown marks a parent object as true, meaning all children are unknown.
+	// after may not contain those keys at all (absent, not null).
+	before := map[string]interface{}{
+		"annotations": map[string]interface{}{
+			"env":     "prod",
+			"version": "1.0",
+		},
+		"name": "myresource",
+	}
+	after := map[string]interface{}{
+		"name": "myresource",
+		// "annotations" is absent — unknown after apply
+	}
+	afterUnknown := map[string]interface{}{
+		"annotations": true, // entire annotations block is unknown
+	}
+
+	diffs := Compute(before, after, afterUnknown, nil, nil)
+
+	// should find 2 diffs: annotations.env and annotations.version, both known after apply
+	if len(diffs) != 2 {
+		t.Fatalf("expected 2 diffs, got %d: %+v", len(diffs), diffs)
+	}
+	for _, d := range diffs {
+		if d.After != "(known after apply)" {
+			t.Errorf("key %q: expected '(known after apply)', got %q", d.Key, d.After)
+		}
+		if !d.KnownAfterApply {
+			t.Errorf("key %q: expected KnownAfterApply=true", d.Key)
+		}
+	}
+}
+
+func TestCompute_SensitiveValue(t *testing.T) {
+	before := map[string]interface{}{
+		"password": "secret123",
+		"name":     "myresource",
+	}
+	after := map[string]interface{}{
+		"password": "newsecret456",
+		"name":     "myresource",
+	}
+	beforeSensitive := map[string]interface{}{
+		"password": true,
+	}
+	afterSensitive := map[string]interface{}{
+		"password": true,
+	}
+
+	diffs := Compute(before, after, nil, beforeSensitive, afterSensitive)
+
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d: %+v", len(diffs), diffs)
+	}
+	d := diffs[0]
+	if d.Key != "password" {
+		t.Errorf("expected key 'password', got %q", d.Key)
+	}
+	if d.Before != "(sensitive value)" {
+		t.Errorf("expected Before '(sensitive value)', got %q", d.Before)
+	}
+	if d.After != "(sensitive value)" {
+		t.Errorf("expected After '(sensitive value)', got %q", d.After)
+	}
+	if !d.Sensitive {
+		t.Error("expected Sensitive to be true")
+	}
+}
+
+func TestCompute_SensitiveValue_ParentKey(t *testing.T) {
+	// before_sensitive marks an entire nested block as sensitive.
+	// Even though display is masked, raw value comparison detects actual changes.
+	before := map[string]interface{}{
+		"credentials": map[string]interface{}{
+			"client_id":     "abc123",
+			"client_secret": "supersecret",
+		},
+	}
+	after := map[string]interface{}{
+		"credentials": map[string]interface{}{
+			"client_id":     "abc123",         // unchanged
+			"client_secret": "newsupersecret", // changed
+		},
+	}
+	beforeSensitive := map[string]interface{}{
+		"credentials": true,
+	}
+	afterSensitive := map[string]interface{}{
+		"credentials": true,
+	}
+
+	diffs := Compute(before, after, nil, beforeSensitive, afterSensitive)
+
+	// Only client_secret changed (raw value differs); client_id did not change
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d: %+v", len(diffs), diffs)
+	}
+	d := diffs[0]
+	if d.Key != "credentials.client_secret" {
+		t.Errorf("expected key 'credentials.client_secret', got %q", d.Key)
+	}
+	if d.Before != "(sensitive value)" {
+		t.Errorf("expected Before '(sensitive value)', got %q", d.Before)
+	}
+	if d.After != "(sensitive value)" {
+		t.Errorf("expected After '(sensitive value)', got %q", d.After)
+	}
+	if !d.Sensitive {
+		t.Error("expected Sensitive=true")
+	}
+}
+
+// TestCompute_BoolSensitive verifies that computeDiffs handles bool values
+// for beforeSensitive/afterSensitive (returned by HCP Terraform when no sensitive attributes exist).
+func TestCompute_BoolSensitive(t *testing.T) {
+	before := map[string]interface{}{
+		"name": "old",
+	}
+	after := map[string]interface{}{
+		"name": "new",
+	}
+
+	// bool false means no sensitive attributes
+	diffs := Compute(before, after, nil, false, false)
+
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d: %+v", len(diffs), diffs)
+	}
+	d := diffs[0]
+	if d.Key != "name" {
+		t.Errorf("expected key 'name', got %q", d.Key)
+	}
+	if d.Before != `"old"` {
+		t.Errorf("expected Before '\"old\"', got %q", d.Before)
+	}
+	if d.After != `"new"` {
+		t.Errorf("expected After '\"new\"', got %q", d.After)
+	}
+	if d.Sensitive {
+		t.Error("expected Sensitive=false")
+	}
+}
+
+// TestCompute_MixedSensitive verifies that computeDiffs handles the case where
+// beforeSensitive is a map but afterSensitive is a bool (or vice versa).
+func TestCompute_MixedSensitive(t *testing.T) {
+	before := map[string]interface{}{
+		"password": "secret",
+		"name":     "old",
+	}
+	after := map[string]interface{}{
+		"password": "newsecret",
+		"name":     "new",
+	}
+
+	// beforeSensitive is a map, afterSensitive is bool false
+	beforeSensitive := map[string]interface{}{
+		"password": true,
+	}
+
+	diffs := Compute(before, after, nil, beforeSensitive, false)
+
+	if len(diffs) != 2 {
+		t.Fatalf("expected 2 diffs, got %d: %+v", len(diffs), diffs)
+	}
+
+	// Sort by key for predictable order
+	diffMap := make(map[string]Attribute)
+	for _, d := range diffs {
+		diffMap[d.Key] = d
+	}
+
+	// "name" should not be sensitive
+	nameD := diffMap["name"]
+	if nameD.Sensitive {
+		t.Error("expected name Sensitive=false")
+	}
+
+	// "password" should be sensitive (marked in beforeSensitive)
+	pwD := diffMap["password"]
+	if !pwD.Sensitive {
+		t.Error("expected password Sensitive=true")
+	}
+	if pwD.Before != "(sensitive value)" {
+		t.Errorf("expected Before '(sensitive value)', got %q", pwD.Before)
+	}
+}
+
+func TestCompute_ListElementAddRemove(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []interface{}
+		after  []interface{}
+		want   map[string][2]string // key -> {before, after}
+	}{
+		{
+			name:   "insert at front",
+			before: []interface{}{"b", "c"},
+			after:  []interface{}{"a", "b", "c"},
+			want:   map[string][2]string{"cidrs.0": {NullValue, `"a"`}},
+		},
+		{
+			name:   "remove from middle",
+			before: []interface{}{"a", "b", "c"},
+			after:  []interface{}{"a", "c"},
+			want:   map[string][2]string{"cidrs.1": {`"b"`, NullValue}},
+		},
+		{
+			name:   "replace and append",
+			before: []interface{}{"a"},
+			after:  []interface{}{"x", "y"},
+			want:   map[string][2]string{"cidrs.0": {`"a"`, `"x"`}, "cidrs.1": {NullValue, `"y"`}},
+		},
+		{
+			name:   "same length compared by position",
+			before: []interface{}{"a", "b"},
+			after:  []interface{}{"a", "c"},
+			want:   map[string][2]string{"cidrs.1": {`"b"`, `"c"`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := Compute(
+				map[string]interface{}{"cidrs": tt.before},
+				map[string]interface{}{"cidrs": tt.after},
+				nil, nil, nil,
+			)
+			if len(diffs) != len(tt.want) {
+				t.Fatalf("expected %d diffs, got %d: %+v", len(tt.want), len(diffs), diffs)
+			}
+			for _, d := range diffs {
+				want, ok := tt.want[d.Key]
+				if !ok {
+					t.Errorf("unexpected diff key %q", d.Key)
+					continue
+				}
+				if d.Before != want[0] || d.After != want[1] {
+					t.Errorf("key %q: got %s => %s, want %s => %s", d.Key, d.Before, d.After, want[0], want[1])
+				}
+			}
+		})
+	}
+}
+
+func TestCompute_ListOfObjects(t *testing.T) {
+	before := map[string]interface{}{
+		"ingress": []interface{}{
+			map[string]interface{}{"port": float64(80)},
+		},
+	}
+	after := map[string]interface{}{
+		"ingress": []interface{}{
+			map[string]interface{}{"port": float64(80)},
+			map[string]interface{}{"port": float64(443)},
+		},
+	}
+
+	diffs := Compute(before, after, nil, nil, nil)
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d: %+v", len(diffs), diffs)
+	}
+	if diffs[0].Key != "ingress.1.port" || diffs[0].Before != NullValue || diffs[0].After != "443" {
+		t.Errorf("unexpected diff: %+v", diffs[0])
+	}
+}
+
+func TestCompute_JSONDocumentString(t *testing.T) {
+	before := map[string]interface{}{
+		"policy": `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"]}]}`,
+	}
+	after := map[string]interface{}{
+		"policy": `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"]}]}`,
+	}
+
+	diffs := Compute(before, after, nil, nil, nil)
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d: %+v", len(diffs), diffs)
+	}
+	d := diffs[0]
+	if d.Key != "policy.Statement.0.Action.1" || d.Before != NullValue || d.After != `"s3:PutObject"` {
+		t.Errorf("unexpected diff: %+v", d)
+	}
+}
+
+func TestCompute_JSONDocumentString_Sensitive(t *testing.T) {
+	before := map[string]interface{}{"policy": `{"secret":"a"}`}
+	after := map[string]interface{}{"policy": `{"secret":"b"}`}
+
+	diffs := Compute(before, after, nil, map[string]interface{}{"policy": true}, nil)
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d: %+v", len(diffs), diffs)
+	}
+	if !diffs[0].Sensitive || diffs[0].Before != SensitiveValue || diffs[0].After != SensitiveValue {
+		t.Errorf("expected masked diff, got %+v", diffs[0])
+	}
+}
+
+func TestCompute_NonJSONString(t *testing.T) {
+	diffs := Compute(
+		map[string]interface{}{"name": "{not json"},
+		map[string]interface{}{"name": "{still not json"},
+		nil, nil, nil,
+	)
+	if len(diffs) != 1 || diffs[0].Key != "name" {
+		t.Errorf("expected a single diff on name, got %+v", diffs)
+	}
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -56,3 +57,15 @@ func PrintJSON(w io.Writer, data any) error {
 	enc.SetIndent("", "  ")
 	return enc.Encode(data)
 }
+
+// PrintCSV writes tabular data as CSV to the writer.
+func PrintCSV(w io.Writer, headers []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
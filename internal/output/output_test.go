@@ -163,3 +163,21 @@ func TestPrint_ColumnAlignment(t *testing.T) {
 		t.Errorf("columns not aligned: header=%d, row1=%d, row2=%d", headerPos, row1Pos, row2Pos)
 	}
 }
+
+func TestPrintCSV(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"NAME", "DESCRIPTION"}
+	rows := [][]string{
+		{"workspace-1", "plain"},
+		{"workspace-2", "has, comma"},
+	}
+
+	if err := output.PrintCSV(&buf, headers, rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "NAME,DESCRIPTION\nworkspace-1,plain\nworkspace-2,\"has, comma\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -13,6 +13,6 @@ var skillsFS embed.FS
 func main() {
 	cmd.SetSkillsFS(skillsFS)
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }